@@ -1,39 +1,113 @@
 package classifier
 
 import (
-    "github.com/dalmirdasilva/AdaBoostGo/statistics"
     "github.com/dalmirdasilva/AdaBoostGo/resample"
-    "github.com/dalmirdasilva/AdaBoostGo/config"
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
     "math"
-    "fmt"
 )
 
 type AdaBoost struct {
-    WeakClassifiers     []WeakClassifier
-    weakLearner         WeakLearner
-    numberOfClassifiers uint
-    weights             []float64
+    WeakClassifiers []WeakClassifier
+    weakLearner     WeakLearner
+    options         Options
+    weights         []float64
+    scores          []float64
+    trainingErrors  []float64
+
+    // Samples tracked after each round when stopping early, with their scores.
+    validation        trainingSet
+    validationScores  []float64
+    validationMetrics []float64
+    stoppedRound      int
+}
+
+// Creates an AdaBoost trained with the options' mode, using the mode's default weak learner.
+func NewAdaBoost(options Options) AdaBoost {
+    return NewAdaBoostWithWeakLearner(NewWeakLearner(options), options)
 }
 
-func NewAdaBoost(numberOfClassifiers uint) AdaBoost {
+// Creates an AdaBoost boosting the weak classifiers generated by the given learner.
+func NewAdaBoostWithWeakLearner(weakLearner WeakLearner, options Options) AdaBoost {
     return AdaBoost{
-        weakLearner: NewWeakLearner(),
+        weakLearner: weakLearner,
         WeakClassifiers: []WeakClassifier{},
-        numberOfClassifiers: numberOfClassifiers,
+        options: options,
         weights: []float64{},
     }
 }
 
+func (c *AdaBoost) GetMode() BoostingMode {
+    return c.options.Mode
+}
+
+func (c *AdaBoost) GetOptions() Options {
+    return c.options
+}
+
+// Gets the error of the strong classifier over the training set after each round of the last Train call.
+func (c *AdaBoost) GetTrainingErrors() []float64 {
+    return c.trainingErrors
+}
+
+// Gets the metric over the validation set after each round of the last Train call, including the rounds after the
+// best one, which were dropped. It is nil when not stopping early.
+func (c *AdaBoost) GetValidationMetrics() []float64 {
+    return c.validationMetrics
+}
+
+// Gets the number of rounds the last Train call ran before stopping, which is the number of classifiers unless it
+// stopped early.
+func (c *AdaBoost) GetStoppedRound() int {
+    return c.stoppedRound
+}
+
+// Sets the samples to stop early on, which must carry their class, -1 or 1, in the last position. They are only used
+// when the options ask for early stopping.
+func (c *AdaBoost) SetValidationSet(samples [][]float64) error {
+    if err := utils.ValidateSamples(samples, 1); err != nil {
+        return err
+    }
+    if err := utils.ValidateBinaryLabels(samples, 1); err != nil {
+        return err
+    }
+    c.validation = denseTrainingSet{samples: samples}
+    return nil
+}
+
+// Sets the samples of a dataset to stop early on, as SetValidationSet does. The metric is weighted by the weights of
+// the samples, when the dataset has them.
+func (c *AdaBoost) SetValidationDataset(dataset *utils.Dataset) error {
+    if err := dataset.Validate(); err != nil {
+        return err
+    }
+    if err := utils.ValidateBinaryLabels(dataset.Samples, 1); err != nil {
+        return err
+    }
+    c.validation = denseTrainingSet{samples: dataset.Samples, weights: dataset.Weights}
+    return nil
+}
+
+// Sets sparse samples to stop early on, as SetValidationSet does.
+func (c *AdaBoost) SetValidationSparse(samples *utils.SparseSamples) error {
+    if err := samples.Validate(); err != nil {
+        return err
+    }
+    if err := samples.ValidateBinaryLabels(); err != nil {
+        return err
+    }
+    c.validation = newSparseTrainingSet(samples)
+    return nil
+}
+
 // All weights should be initialized with the same distribution.
-func (c *AdaBoost) initializeWeights(samples [][]float64) {
-    samplesLength := uint(len(samples))
+func (c *AdaBoost) initializeWeights(samples trainingSet) {
+    samplesLength := uint(samples.size())
     c.weights = make([]float64, samplesLength)
     var positiveWeight, negativeWeight float64
     negativeWeight = 1 / float64(samplesLength)
     positiveWeight = negativeWeight
-    if config.INCORPORATE_COST_SENSITIVE_LEARNING {
-        analyzer := statistics.NewFeaturesAnalyzer()
-        _, distribution := analyzer.Analyze(samples)
+    if c.options.IncorporateCostSensitiveLearning {
+        distribution := classDistribution(samples)
         positiveRate := float64(distribution.Positive) / float64(samplesLength)
         negativeRate := float64(distribution.Negative) / float64(samplesLength)
         normalizingConstant := (float64(distribution.Negative) * positiveRate) + (float64(distribution.Positive) * negativeRate)
@@ -43,14 +117,26 @@ func (c *AdaBoost) initializeWeights(samples [][]float64) {
         positiveWeight = positiveRate / float64(normalizingConstant)
         negativeWeight = negativeRate / float64(normalizingConstant)
     }
-    for i, sample := range samples {
-        y := sample[len(sample) - 1];
+    for i := range c.weights {
+        y := samples.label(i)
         if y == -1 {
             c.weights[i] = positiveWeight
         } else {
             c.weights[i] = negativeWeight
         }
     }
+
+    // D_{1}(i) is scaled by the weight of sample i.
+    if sampleWeights := samples.sampleWeights(); sampleWeights != nil {
+        sum := 0.0
+        for i := range c.weights {
+            c.weights[i] *= sampleWeights[i]
+            sum += c.weights[i]
+        }
+        for i := range c.weights {
+            c.weights[i] /= sum
+        }
+    }
 }
 
 // Update the distribution based on the performance.
@@ -58,15 +144,19 @@ func (c *AdaBoost) initializeWeights(samples [][]float64) {
 // Computes the following equation:
 // D_{t+1}(i)=\frac{D_{t}(i)e(-\alpha_{t}y_{i}h_{t}(x_{i}))}{Z_{t}}
 //
-// where Z t is a normalization factor to keep D_{t+1} a distribution. Note the careful evaluation of the term inside of
+// where Z t is a normalization factor to keep D_{t+1} a distribution, and α_{t} is already shrunk by the learning
+// rate. Note the careful evaluation of the term inside of
 // the exp based on the possible {−1, +1} values of the label.
-func (c *AdaBoost) updateWeights(classifier WeakClassifier, samples[][]float64) {
+//
+// Confidence-rated classifiers of Real and Gentle AdaBoost carry their confidence in h_{t}(x_{i}) and have α_{t} = 1.
+// Gentle AdaBoost bounds h_{t}(x_{i}) to [-1, 1], which keeps noisy samples from having their weights exploding.
+func (c *AdaBoost) updateWeights(classifier WeakClassifier, samples trainingSet) {
     sum := float64(0)
-    for i, sample := range samples {
-        y := sample[len(sample) - 1]
+    for i := range c.weights {
+        y := samples.label(i)
 
         // D_{t+1}(i)=\frac{D_{t}(i)e(-\alpha_{t}y_{i}h_{t}(x_{i}))}{Z_{t}}.
-        c.weights[i] *= math.Exp(-(classifier.GetAlpha()) * float64(classifier.Classify(sample)) * y)
+        c.weights[i] *= math.Exp(-classifier.ClassifyWithAlpha(samples.sample(i)) * y)
 
         // Summing up the Z_{t}.
         sum += c.weights[i]
@@ -81,32 +171,180 @@ func (c *AdaBoost) updateWeights(classifier WeakClassifier, samples[][]float64)
 
 // Learn!
 //
+// Samples must carry their class, -1 or 1, in the last position. When a weak classifier cannot be generated the
+// training stops with an error, keeping the weak classifiers of the previous rounds.
+//
 // @param samples
-func (c *AdaBoost) Train(samples [][]float64) {
+func (c *AdaBoost) Train(samples [][]float64) error {
 
-    if config.OVER_SAMPLING_TRAINING_SET {
+    if err := utils.ValidateSamples(samples, 1); err != nil {
+        return err
+    }
+    if err := utils.ValidateBinaryLabels(samples, 1); err != nil {
+        return err
+    }
+
+    if c.options.OverSamplingTrainingSet {
         resampler := resample.NewResampler()
-        samples = resampler.OverSample(samples)
+        var err error
+        if samples, err = resampler.OverSample(samples); err != nil {
+            return err
+        }
     }
+    return c.train(denseTrainingSet{samples: samples})
+}
 
-    c.initializeWeights(samples)
+// Learn from the samples of a dataset, as Train does. The initial distribution is proportional to the weights of the
+// samples, when the dataset has them.
+func (c *AdaBoost) TrainDataset(dataset *utils.Dataset) error {
 
-    // Build T classifiers.
-    for i := uint(0); i < c.numberOfClassifiers; i++ {
+    if err := dataset.Validate(); err != nil {
+        return err
+    }
+    if err := utils.ValidateBinaryLabels(dataset.Samples, 1); err != nil {
+        return err
+    }
 
-        // Call the learner and receive the built classifier.
-        weakClassifier := c.weakLearner.GenerateWeakClassifier(samples, c.weights)
+    if c.options.OverSamplingTrainingSet {
+        resampler := resample.NewResampler()
+        var err error
+        if dataset, err = resampler.OverSampleDataset(dataset); err != nil {
+            return err
+        }
+    }
+    return c.train(denseTrainingSet{samples: dataset.Samples, weights: dataset.Weights})
+}
+
+// Learn from sparse samples, as Train does.
+//
+// The weak learner must implement SparseWeakLearner, and SparseRegressionLearner for LogitBoost. Each round takes
+// time proportional to the features other than 0.
+func (c *AdaBoost) TrainSparse(samples *utils.SparseSamples) error {
+
+    if err := samples.Validate(); err != nil {
+        return err
+    }
+    if err := samples.ValidateBinaryLabels(); err != nil {
+        return err
+    }
+
+    if c.options.OverSamplingTrainingSet {
+        resampler := resample.NewResampler()
+        var err error
+        if samples, err = resampler.OverSampleSparse(samples); err != nil {
+            return err
+        }
+    }
+    return c.train(newSparseTrainingSet(samples))
+}
+
+// Runs the boosting rounds over a validated training set.
+func (c *AdaBoost) train(samples trainingSet) error {
+
+    // All modes keep the scores of the training samples to track the training error.
+    c.scores = make([]float64, samples.size())
+    c.trainingErrors = []float64{}
+    c.validationMetrics = nil
+    stoppingEarly := c.options.EarlyStoppingRounds > 0 && c.validation != nil
+    if stoppingEarly {
+        c.validationScores = make([]float64, c.validation.size())
+        c.validationMetrics = []float64{}
+    }
+    firstRound, bestRound := len(c.WeakClassifiers), 0
+
+    // However training ends, a learner error included, the rounds after the best one are dropped.
+    defer func() {
+        c.stoppedRound = len(c.WeakClassifiers) - firstRound
+        if stoppingEarly && len(c.validationMetrics) > 0 {
+            c.WeakClassifiers = c.WeakClassifiers[:firstRound + bestRound + 1]
+            c.trainingErrors = c.trainingErrors[:bestRound + 1]
+        }
+    }()
+    if c.options.Mode == LOGITBOOST {
+        c.initializeScores(samples)
+    } else {
+        c.initializeWeights(samples)
+    }
+
+    samples.prepare(c.weakLearner)
+
+    // Build T classifiers, or fewer when the validation metric stops improving.
+    for i := uint(0); i < c.options.NumberOfClassifiers; i++ {
+
+        var weakClassifier WeakClassifier
+        var err error
+        if c.options.Mode == LOGITBOOST {
 
-        // Computes the alpha for the built classifier.
-        weakClassifier.ComputeAlpha()
+            // Fits a regression to the working responses and updates the scores.
+            if weakClassifier, err = c.generateLogitRegressor(samples); err != nil {
+                return err
+            }
+        } else {
+
+            // Call the learner and receive the built classifier.
+            if weakClassifier, err = samples.generateWeakClassifier(c.weakLearner, c.weights); err != nil {
+                return err
+            }
 
-        // Updates the weights.
-        c.updateWeights(weakClassifier, samples)
+            // Computes the alpha for the built classifier, shrunk by the learning rate ν, so the weights and the scores
+            // both take the damped step ν·α_{t}.
+            weakClassifier.ComputeAlpha()
+            weakClassifier.SetAlpha(c.options.LearningRate * weakClassifier.GetAlpha())
+
+            // Updates the weights.
+            c.updateWeights(weakClassifier, samples)
+        }
 
         // Save the classifier.
         c.WeakClassifiers = append(c.WeakClassifiers, weakClassifier)
+        c.updateScores(weakClassifier, samples)
+        c.trainingErrors = append(c.trainingErrors, c.computeTrainingError(samples))
+        if !stoppingEarly {
+            continue
+        }
+        c.validationMetrics = append(c.validationMetrics, c.computeValidationMetric(weakClassifier))
+        if round := len(c.validationMetrics) - 1; c.validationMetrics[round] < c.validationMetrics[bestRound] {
+            bestRound = round
+        } else if uint(round - bestRound) >= c.options.EarlyStoppingRounds {
+            break
+        }
+    }
+    return nil
+}
+
+// Adds the new weak classifier to the scores of the validation samples and computes their metric, weighted by their
+// weights.
+func (c *AdaBoost) computeValidationMetric(weakClassifier WeakClassifier) float64 {
+    weights := c.validation.sampleWeights()
+    sum, totalWeight := 0.0, 0.0
+    for i := range c.validationScores {
+        c.validationScores[i] += weakClassifier.ClassifyWithAlpha(c.validation.sample(i))
+        weight := 1.0
+        if weights != nil {
+            weight = weights[i]
+        }
+        sum += weight * c.options.EarlyStoppingMetric.loss(c.validation.label(i), c.validationScores[i])
+        totalWeight += weight
+    }
+    return sum / totalWeight
+}
+
+// F_{t}(x_{i}) = F_{t-1}(x_{i}) + \alpha_{t}h_{t}(x_{i})
+func (c *AdaBoost) updateScores(weakClassifier WeakClassifier, samples trainingSet) {
+    for i := range c.scores {
+        c.scores[i] += weakClassifier.ClassifyWithAlpha(samples.sample(i))
+    }
+}
 
+// Computes the fraction of the training samples misclassified by sign(F_{t}(x_{i})).
+func (c *AdaBoost) computeTrainingError(samples trainingSet) float64 {
+    misclassified := 0
+    for i := range c.scores {
+        if (c.scores[i] > 0) != (samples.label(i) > 0) {
+            misclassified++
+        }
     }
+    return float64(misclassified) / float64(len(c.scores))
 }
 
 // H(x)=sign(\sum_{t=1}^{T}{\alpha_{t}h_{t}(x)})
@@ -116,3 +354,13 @@ func (c *AdaBoost) Classify(sample []float64) (score float64) {
     }
     return
 }
+
+// Estimates the probability of the sample being positive.
+// Computes the following equation:
+// P(y = 1|x) = \frac{1}{1 + e^{-2F(x)}}
+//
+// where F(x) is the score. The estimate is calibrated for LogitBoost, which fits F(x) as half the log-odds. Other
+// modes approximate the same quantity.
+func (c *AdaBoost) PredictProba(sample []float64) float64 {
+    return 1 / (1 + math.Exp(-2 * c.Classify(sample)))
+}
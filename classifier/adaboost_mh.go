@@ -0,0 +1,132 @@
+package classifier
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+    "fmt"
+    "math"
+)
+
+// AdaBoost.MH for multi-label classification.
+//
+// Samples carry their L labels, each -1 or 1, in the last L positions. Boosting minimizes the Hamming loss by
+// keeping a distribution over the (sample, label) pairs, and each round adds a stump shared by all labels with a
+// vote per label.
+type AdaBoostMH struct {
+    WeakClassifiers     []*MultiLabelStump
+    weakLearner         *MultiLabelStumpLearner
+    numberOfLabels      uint
+    numberOfClassifiers uint
+    learningRate        float64
+    weights             [][]float64
+}
+
+func NewAdaBoostMH(numberOfLabels uint, options Options) AdaBoostMH {
+    return AdaBoostMH{
+        WeakClassifiers: []*MultiLabelStump{},
+        weakLearner: NewMultiLabelStumpLearner(options),
+        numberOfLabels: numberOfLabels,
+        numberOfClassifiers: options.NumberOfClassifiers,
+        learningRate: options.LearningRate,
+    }
+}
+
+func (c *AdaBoostMH) GetNumberOfLabels() uint {
+    return c.numberOfLabels
+}
+
+// Splits the labels, the last L positions, from the samples.
+func (c *AdaBoostMH) extractLabels(samples [][]float64) [][]float64 {
+    labels := make([][]float64, len(samples))
+    for i, sample := range samples {
+        labels[i] = sample[len(sample) - int(c.numberOfLabels):]
+    }
+    return labels
+}
+
+// All (sample, label) pairs start with the same weight.
+func (c *AdaBoostMH) initializeWeights(samples [][]float64) {
+    c.weights = make([][]float64, len(samples))
+    weight := 1 / float64(len(samples) * int(c.numberOfLabels))
+    for i := range samples {
+        c.weights[i] = make([]float64, c.numberOfLabels)
+        for l := range c.weights[i] {
+            c.weights[i][l] = weight
+        }
+    }
+}
+
+// Update the distribution based on the performance.
+//
+// Computes the following equation:
+// D_{t+1}(i, l)=\frac{D_{t}(i, l)e(-\alpha_{t}y_{i,l}h_{t}(x_{i}, l))}{Z_{t}}
+func (c *AdaBoostMH) updateWeights(weakClassifier *MultiLabelStump, samples [][]float64, labels [][]float64) {
+    sum := 0.0
+    for i, sample := range samples {
+        for l := range c.weights[i] {
+            c.weights[i][l] *= math.Exp(-weakClassifier.ClassifyLabelWithAlpha(sample, l) * labels[i][l])
+            sum += c.weights[i][l]
+        }
+    }
+    for i := range c.weights {
+        for l := range c.weights[i] {
+            c.weights[i][l] /= sum
+        }
+    }
+}
+
+// Learn!
+func (c *AdaBoostMH) Train(samples [][]float64) error {
+    if c.numberOfLabels < 1 {
+        return fmt.Errorf("%w: at least one label is needed", utils.ErrInvalidLabel)
+    }
+    if err := utils.ValidateSamples(samples, int(c.numberOfLabels)); err != nil {
+        return err
+    }
+    if err := utils.ValidateBinaryLabels(samples, int(c.numberOfLabels)); err != nil {
+        return err
+    }
+    labels := c.extractLabels(samples)
+    numberOfFeatures := uint(len(samples[0])) - c.numberOfLabels
+
+    c.initializeWeights(samples)
+    c.weakLearner.Prepare(samples, int(numberOfFeatures))
+
+    // Build T classifiers.
+    for i := uint(0); i < c.numberOfClassifiers; i++ {
+        weakClassifier, err := c.weakLearner.GenerateWeakClassifier(samples, numberOfFeatures, labels, c.weights)
+        if err != nil {
+            return err
+        }
+
+        // The alpha is shrunk by the learning rate ν, so the weights and the scores both take the damped step.
+        weakClassifier.ComputeAlpha()
+        weakClassifier.SetAlpha(c.learningRate * weakClassifier.GetAlpha())
+        c.updateWeights(weakClassifier, samples, labels)
+        c.WeakClassifiers = append(c.WeakClassifiers, weakClassifier)
+    }
+    return nil
+}
+
+// Computes the score of each label:
+// f_{l}(x)=\sum_{t=1}^{T}{\alpha_{t}h_{t}(x, l)}
+func (c *AdaBoostMH) Scores(sample []float64) []float64 {
+    scores := make([]float64, c.numberOfLabels)
+    for _, weakClassifier := range c.WeakClassifiers {
+        for l := range scores {
+            scores[l] += weakClassifier.ClassifyLabelWithAlpha(sample, l)
+        }
+    }
+    return scores
+}
+
+// H(x, l)=sign(f_{l}(x))
+func (c *AdaBoostMH) Classify(sample []float64) []int {
+    labels := make([]int, c.numberOfLabels)
+    for l, score := range c.Scores(sample) {
+        labels[l] = -1
+        if score > 0 {
+            labels[l] = 1
+        }
+    }
+    return labels
+}
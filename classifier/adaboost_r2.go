@@ -0,0 +1,207 @@
+package classifier
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+    "fmt"
+    "math"
+    "sort"
+)
+
+// Loss function of AdaBoost.R2, applied to the prediction errors scaled to [0, 1].
+type RegressionLoss int
+
+const (
+    LINEAR_LOSS RegressionLoss = iota
+    SQUARE_LOSS
+    EXPONENTIAL_LOSS
+)
+
+var regressionLossNames = map[RegressionLoss]string{
+    LINEAR_LOSS: "linear",
+    SQUARE_LOSS: "square",
+    EXPONENTIAL_LOSS: "exponential",
+}
+
+func (l RegressionLoss) String() string {
+    if name, ok := regressionLossNames[l]; ok {
+        return name
+    }
+    return fmt.Sprintf("RegressionLoss(%d)", int(l))
+}
+
+// Parses the name of a regression loss, as persisted with the model.
+func ParseRegressionLoss(name string) (RegressionLoss, bool) {
+    for loss, lossName := range regressionLossNames {
+        if lossName == name {
+            return loss, true
+        }
+    }
+    return LINEAR_LOSS, false
+}
+
+// Computes L_{i} from the error scaled by the maximum error, e = |y_{i} - f(x_{i})| / D.
+func (l RegressionLoss) compute(scaledError float64) float64 {
+    switch l {
+    case SQUARE_LOSS:
+        return scaledError * scaledError
+    case EXPONENTIAL_LOSS:
+        return 1 - math.Exp(-scaledError)
+    }
+    return scaledError
+}
+
+// AdaBoost.R2 (Drucker, 1997) for continuous targets.
+//
+// Samples carry their target in the last position. Each round fits a regressor to the weighted samples, measures
+// its average loss \bar{L}_{t} = \sum_{i}D_{t}(i)L_{i}, and stops when \bar{L}_{t} \geq 1/2. The regressors are
+// weighted by \nu\ln(1/\beta_{t}) with \beta_{t} = \bar{L}_{t} / (1 - \bar{L}_{t}), ν being the learning rate, and the
+// prediction is their weighted median.
+type AdaBoostR2 struct {
+    WeakRegressors     []WeakClassifier
+    RegressorWeights   []float64
+    weakLearner        RegressionLearner
+    loss               RegressionLoss
+    numberOfRegressors uint
+    learningRate       float64
+    weights            []float64
+}
+
+// Creates an AdaBoost.R2 boosting regression trees of the default depth.
+// The number of regressors is the options' number of classifiers.
+func NewAdaBoostR2(loss RegressionLoss, options Options) AdaBoostR2 {
+    return NewAdaBoostR2WithWeakLearner(NewRegressionTreeLearner(DEFAULT_REGRESSION_TREE_DEPTH, options), loss, options)
+}
+
+// Creates an AdaBoost.R2 boosting the regressors generated by the given learner.
+func NewAdaBoostR2WithWeakLearner(weakLearner RegressionLearner, loss RegressionLoss, options Options) AdaBoostR2 {
+    return AdaBoostR2{
+        WeakRegressors: []WeakClassifier{},
+        RegressorWeights: []float64{},
+        weakLearner: weakLearner,
+        loss: loss,
+        numberOfRegressors: options.NumberOfClassifiers,
+        learningRate: options.LearningRate,
+        weights: []float64{},
+    }
+}
+
+func (c *AdaBoostR2) GetLoss() RegressionLoss {
+    return c.loss
+}
+
+// Learn!
+//
+// Samples must carry a finite target in the last position.
+func (c *AdaBoostR2) Train(samples [][]float64) error {
+    if err := utils.ValidateSamples(samples, 1); err != nil {
+        return err
+    }
+    targets := make([]float64, len(samples))
+    c.weights = make([]float64, len(samples))
+    for i, sample := range samples {
+        targets[i] = sample[len(sample) - 1]
+        if math.IsNaN(targets[i]) || math.IsInf(targets[i], 0) {
+            return &utils.RowError{Row: i, Column: len(sample) - 1, Err: fmt.Errorf("%w: %v is not finite", utils.ErrInvalidLabel, targets[i])}
+        }
+        c.weights[i] = 1 / float64(len(samples))
+    }
+
+    if preparable, ok := c.weakLearner.(PreparableWeakLearner); ok {
+        preparable.Prepare(samples)
+    }
+
+    losses := make([]float64, len(samples))
+
+    // Build T regressors.
+    for t := uint(0); t < c.numberOfRegressors; t++ {
+        regressor, err := c.weakLearner.GenerateRegressor(samples, targets, c.weights)
+        if err != nil {
+            return err
+        }
+        averageLoss := c.computeLosses(regressor, samples, targets, losses)
+        regressor.SetError(averageLoss)
+
+        // A perfect regressor is all it takes.
+        if averageLoss <= 0 {
+            c.WeakRegressors = []WeakClassifier{regressor}
+            c.RegressorWeights = []float64{1}
+            break
+        }
+
+        // The regressor is no better than guessing. It is only kept when there is nothing else.
+        if averageLoss >= 0.5 {
+            if len(c.WeakRegressors) == 0 {
+                c.WeakRegressors = append(c.WeakRegressors, regressor)
+                c.RegressorWeights = append(c.RegressorWeights, 1)
+            }
+            break
+        }
+
+        beta := averageLoss / (1 - averageLoss)
+        c.updateWeights(beta, losses)
+        c.WeakRegressors = append(c.WeakRegressors, regressor)
+        c.RegressorWeights = append(c.RegressorWeights, c.learningRate * math.Log(1 / beta))
+    }
+    return nil
+}
+
+// Computes the loss L_{i} of each sample, relative to the maximum error D = \max_{i}|y_{i} - f_{t}(x_{i})|, and
+// returns the average loss \bar{L}_{t}.
+func (c *AdaBoostR2) computeLosses(regressor WeakClassifier, samples [][]float64, targets []float64, losses []float64) float64 {
+    maximumError := 0.0
+    for i, sample := range samples {
+        losses[i] = math.Abs(targets[i] - regressor.ClassifyWithAlpha(sample))
+        maximumError = math.Max(maximumError, losses[i])
+    }
+    if maximumError == 0 {
+        return 0
+    }
+    averageLoss := 0.0
+    for i := range losses {
+        losses[i] = c.loss.compute(losses[i] / maximumError)
+        averageLoss += c.weights[i] * losses[i]
+    }
+    return averageLoss
+}
+
+// Update the distribution based on the performance, lowering the weight of the well predicted samples.
+//
+// Computes the following equation, ν being the learning rate:
+// D_{t+1}(i)=\frac{D_{t}(i)\beta_{t}^{\nu(1 - L_{i})}}{Z_{t}}
+func (c *AdaBoostR2) updateWeights(beta float64, losses []float64) {
+    sum := 0.0
+    for i := range c.weights {
+        c.weights[i] *= math.Pow(beta, c.learningRate * (1 - losses[i]))
+        sum += c.weights[i]
+    }
+    for i := range c.weights {
+        c.weights[i] /= sum
+    }
+}
+
+// Predicts the target of the sample as the weighted median of the regressors' predictions: the smallest prediction
+// f_{t}(x) such that the weights of the predictions up to it reach half of the total weight.
+func (c *AdaBoostR2) Predict(sample []float64) float64 {
+    if len(c.WeakRegressors) == 0 {
+        return 0
+    }
+    order := make([]int, len(c.WeakRegressors))
+    predictions := make([]float64, len(c.WeakRegressors))
+    totalWeight := 0.0
+    for t, regressor := range c.WeakRegressors {
+        order[t] = t
+        predictions[t] = regressor.ClassifyWithAlpha(sample)
+        totalWeight += c.RegressorWeights[t]
+    }
+    sort.Slice(order, func(a, b int) bool {
+        return predictions[order[a]] < predictions[order[b]]
+    })
+    cumulativeWeight := 0.0
+    for _, t := range order {
+        cumulativeWeight += c.RegressorWeights[t]
+        if cumulativeWeight >= totalWeight / 2 {
+            return predictions[t]
+        }
+    }
+    return predictions[order[len(order) - 1]]
+}
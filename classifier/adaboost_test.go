@@ -0,0 +1,93 @@
+package classifier
+
+import (
+    "math/rand"
+    "testing"
+)
+
+// Flips the labels of samples, so a validation set made of them gets worse as training gets better.
+func flipLabels(samples [][]float64) [][]float64 {
+    flipped := make([][]float64, len(samples))
+    for i, sample := range samples {
+        flipped[i] = append([]float64{}, sample...)
+        flipped[i][len(sample) - 1] = -sample[len(sample) - 1]
+    }
+    return flipped
+}
+
+func TestAdaBoostEarlyStopping(t *testing.T) {
+    random := rand.New(rand.NewSource(1))
+    samples := randomSamples(random, 100, 3, 20, 0, 0)
+    tests := []struct {
+        name       string
+        mode       BoostingMode
+        metric     StoppingMetric
+        rounds     uint
+        validation [][]float64
+
+        // Best round expected, or -1 when any may be.
+        bestRound int
+    }{
+        {"flipped labels", DISCRETE_ADABOOST, ERROR_METRIC, 3, flipLabels(samples), 0},
+        {"flipped labels with log-loss", REAL_ADABOOST, LOG_LOSS_METRIC, 2, flipLabels(samples), -1},
+        {"flipped labels with exponential loss", GENTLE_ADABOOST, EXPONENTIAL_LOSS_METRIC, 1, flipLabels(samples), 0},
+        {"held out samples", DISCRETE_ADABOOST, ERROR_METRIC, 2, randomSamples(random, 50, 3, 20, 0, 0), -1},
+        {"held out samples with log-loss", REAL_ADABOOST, LOG_LOSS_METRIC, 3, randomSamples(random, 50, 3, 20, 0, 0), -1},
+        {"logitboost", LOGITBOOST, EXPONENTIAL_LOSS_METRIC, 2, randomSamples(random, 50, 3, 20, 0, 0), -1},
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            options := DefaultOptions()
+            options.Mode = test.mode
+            options.NumberOfClassifiers = 20
+            options.EarlyStoppingRounds = test.rounds
+            options.EarlyStoppingMetric = test.metric
+            adaBoost := NewAdaBoost(options)
+            if err := adaBoost.SetValidationSet(test.validation); err != nil {
+                t.Fatal(err)
+            }
+            if err := adaBoost.Train(samples); err != nil {
+                t.Fatal(err)
+            }
+
+            metrics := adaBoost.GetValidationMetrics()
+            if len(metrics) != adaBoost.GetStoppedRound() {
+                t.Fatalf("%d validation metrics for %d rounds", len(metrics), adaBoost.GetStoppedRound())
+            }
+            bestRound := 0
+            for round, metric := range metrics {
+                if metric < metrics[bestRound] {
+                    bestRound = round
+                }
+            }
+            if test.bestRound >= 0 && bestRound != test.bestRound {
+                t.Errorf("best round is %d, want %d", bestRound, test.bestRound)
+            }
+            if len(adaBoost.WeakClassifiers) != bestRound + 1 || len(adaBoost.GetTrainingErrors()) != bestRound + 1 {
+                t.Errorf("kept %d weak classifiers and %d training errors, want %d", len(adaBoost.WeakClassifiers), len(adaBoost.GetTrainingErrors()), bestRound + 1)
+            }
+            if stoppedRound := adaBoost.GetStoppedRound(); stoppedRound < int(options.NumberOfClassifiers) && stoppedRound != bestRound + 1 + int(test.rounds) {
+                t.Errorf("stopped after %d rounds, %d after the best one, want %d", stoppedRound, stoppedRound - bestRound - 1, test.rounds)
+            }
+        })
+    }
+}
+
+func TestAdaBoostWithoutEarlyStopping(t *testing.T) {
+    random := rand.New(rand.NewSource(2))
+    samples := randomSamples(random, 100, 3, 20, 0, 0)
+    options := DefaultOptions()
+    options.NumberOfClassifiers = 10
+    adaBoost := NewAdaBoost(options)
+
+    // The validation set is ignored when not asking for early stopping.
+    if err := adaBoost.SetValidationSet(flipLabels(samples)); err != nil {
+        t.Fatal(err)
+    }
+    if err := adaBoost.Train(samples); err != nil {
+        t.Fatal(err)
+    }
+    if len(adaBoost.WeakClassifiers) != 10 || adaBoost.GetStoppedRound() != 10 || adaBoost.GetValidationMetrics() != nil {
+        t.Errorf("kept %d weak classifiers after %d rounds with validation metrics %v", len(adaBoost.WeakClassifiers), adaBoost.GetStoppedRound(), adaBoost.GetValidationMetrics())
+    }
+}
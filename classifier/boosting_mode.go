@@ -0,0 +1,63 @@
+package classifier
+
+import "fmt"
+
+// Variant of the boosting algorithm used to train the classifier.
+type BoostingMode int
+
+const (
+
+    // Each weak classifier outputs ±1 weighted by α_{t}.
+    DISCRETE_ADABOOST BoostingMode = iota
+
+    // Each weak classifier outputs a confidence-rated real value.
+    REAL_ADABOOST
+
+    // Each weak classifier is a regression fitted by weighted least squares, outputting values in [-1, 1].
+    GENTLE_ADABOOST
+
+    // Each weak classifier is a regression fitted to the working responses of the binomial log-likelihood, so the
+    // score estimates half the log-odds of the positive class.
+    LOGITBOOST
+)
+
+var boostingModeNames = map[BoostingMode]string{
+    DISCRETE_ADABOOST: "discrete",
+    REAL_ADABOOST: "real",
+    GENTLE_ADABOOST: "gentle",
+    LOGITBOOST: "logit",
+}
+
+func (m BoostingMode) String() string {
+    if name, ok := boostingModeNames[m]; ok {
+        return name
+    }
+    return fmt.Sprintf("BoostingMode(%d)", int(m))
+}
+
+// Parses the name of a boosting mode, as persisted with the model.
+// An empty name is the discrete mode, used by models written before the modes existed.
+func ParseBoostingMode(name string) (BoostingMode, bool) {
+    if name == "" {
+        return DISCRETE_ADABOOST, true
+    }
+    for mode, modeName := range boostingModeNames {
+        if modeName == name {
+            return mode, true
+        }
+    }
+    return DISCRETE_ADABOOST, false
+}
+
+func (m BoostingMode) MarshalText() ([]byte, error) {
+    return []byte(m.String()), nil
+}
+
+func (m *BoostingMode) UnmarshalText(text []byte) error {
+    mode, ok := ParseBoostingMode(string(text))
+    if !ok {
+        return fmt.Errorf("unknown boosting mode %s", text)
+    }
+    *m = mode
+    return nil
+}
@@ -0,0 +1,117 @@
+package classifier
+
+import (
+    "math"
+    "sort"
+)
+
+// Tells if a value goes above a split. Missing values, NaN, go above it when missingAbove is set. When categories is
+// not nil the split is categorical, and the values go above it when they are one of its sorted categories.
+func goesAbove(value, split float64, categories []float64, missingAbove bool) bool {
+    if math.IsNaN(value) {
+        return missingAbove
+    }
+    if categories != nil {
+        return containsCategory(categories, value)
+    }
+    return value > split
+}
+
+// Tells if a value is one of the sorted categories.
+func containsCategory(categories []float64, value float64) bool {
+    i := sort.SearchFloat64s(categories, value)
+    return i < len(categories) && categories[i] == value
+}
+
+// Categories of a categorical feature of a training set and the category of each sample.
+type categoricalColumn struct {
+
+    // Distinct categories, in increasing order.
+    values []float64
+
+    // Position in values of the category of each sample, -1 when the sample misses the feature.
+    codes []int
+}
+
+// Builds the categories of a feature given the samples holding a value, sorted by it, and those missing it. The
+// other samples hold 0.
+func newCategoricalColumn(numberOfSamples int, rows []int, values []float64, missing []int) *categoricalColumn {
+    c := &categoricalColumn{codes: make([]int, numberOfSamples)}
+    for i, value := range values {
+        if i == 0 || value != values[i - 1] {
+            c.values = append(c.values, value)
+        }
+    }
+    if len(rows) + len(missing) < numberOfSamples && !containsCategory(c.values, 0) {
+        i := sort.SearchFloat64s(c.values, 0)
+        c.values = append(c.values[:i], append([]float64{0}, c.values[i:]...)...)
+    }
+    zero := sort.SearchFloat64s(c.values, 0)
+    for i := range c.codes {
+        c.codes[i] = zero
+    }
+    for i, row := range rows {
+        c.codes[row] = sort.SearchFloat64s(c.values, values[i])
+    }
+    for _, row := range missing {
+        c.codes[row] = -1
+    }
+    return c
+}
+
+// Sums the weights of each class of the samples of each category, and of the samples missing the feature.
+func (c *categoricalColumn) sumClassWeights(weights []float64, label func(row int) float64) (positive, negative []float64, positiveMissing, negativeMissing float64) {
+    positive = make([]float64, len(c.values))
+    negative = make([]float64, len(c.values))
+    for row, code := range c.codes {
+        switch {
+        case code < 0 && label(row) > 0:
+            positiveMissing += weights[row]
+        case code < 0:
+            negativeMissing += weights[row]
+        case label(row) > 0:
+            positive[code] += weights[row]
+        default:
+            negative[code] += weights[row]
+        }
+    }
+    return
+}
+
+// Gets the categories at the given positions, in increasing order.
+func (c *categoricalColumn) categoriesAt(positions []int) []float64 {
+    categories := make([]float64, len(positions))
+    for i, position := range positions {
+        categories[i] = c.values[position]
+    }
+    sort.Float64s(categories)
+    return categories
+}
+
+// Gets the weighted rate of the positive samples of each category, 0 for the categories without weight.
+// \rho_{c} = \frac{W^{+}_{c}}{W^{+}_{c} + W^{-}_{c}}
+func positiveRates(positive, negative []float64) []float64 {
+    rates := make([]float64, len(positive))
+    for c := range rates {
+        if weight := positive[c] + negative[c]; weight > 0 {
+            rates[c] = positive[c] / weight
+        }
+    }
+    return rates
+}
+
+// Gets the positions of the categories sorted by increasing score, categories with equal scores keeping their order.
+//
+// Splitting the categories between the first ones of this order and the rest gives the optimal subset split when
+// the scores are the weighted positive rates, or the mean targets for regression, so only len(scores) - 1 subsets
+// are evaluated instead of 2^{len(scores) - 1}.
+func orderCategories(scores []float64) []int {
+    order := make([]int, len(scores))
+    for i := range order {
+        order[i] = i
+    }
+    sort.SliceStable(order, func(a, b int) bool {
+        return scores[order[a]] < scores[order[b]]
+    })
+    return order
+}
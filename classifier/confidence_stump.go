@@ -0,0 +1,146 @@
+package classifier
+
+import (
+    "fmt"
+    "math"
+)
+
+const CONFIDENCE_STUMP_KIND = "confidence_stump"
+
+// Confidence-rated single threshold weak classifier.
+//
+// Instead of voting ±1, it outputs a real value for each side of the split: the left value for samples less than
+// or equal to the split and the right value for samples above it. The sign is the predicted class and the
+// magnitude is the confidence of the prediction. Samples missing the feature, holding NaN, get the left value unless
+// missingAbove is set.
+//
+// Stumps of categorical features have categories instead of a split: samples holding one of them get the right value
+// and the others the left one.
+type ConfidenceStump struct {
+    featureNumber uint
+    split         float64
+    categories    []float64
+    leftValue     float64
+    rightValue    float64
+    missingAbove  bool
+    error         float64
+    alpha         float64
+}
+
+func NewConfidenceStump(featureNumber uint, split, leftValue, rightValue float64) *ConfidenceStump {
+    return &ConfidenceStump{featureNumber: featureNumber, split: split, leftValue: leftValue, rightValue: rightValue, alpha: 1}
+}
+
+// The confidence is already part of the leaf values, so α_{t} = 1.
+func (c *ConfidenceStump) ComputeAlpha() {
+    c.alpha = 1
+}
+
+// Gets the real value of the side of the split the sample falls into.
+func (c *ConfidenceStump) Value(sample []float64) float64 {
+    if goesAbove(sample[c.featureNumber], c.split, c.categories, c.missingAbove) {
+        return c.rightValue
+    }
+    return c.leftValue
+}
+
+func (c *ConfidenceStump) Classify(sample []float64) int {
+    if c.Value(sample) > 0 {
+        return 1
+    }
+    return -1
+}
+
+func (c *ConfidenceStump) ClassifyWithAlpha(sample []float64) float64 {
+    return c.Value(sample) * c.alpha
+}
+
+func (c *ConfidenceStump) GetFeatureNumber() uint {
+    return c.featureNumber
+}
+
+func (c *ConfidenceStump) GetFeatureNumbers() []uint {
+    return []uint{c.featureNumber}
+}
+
+func (c *ConfidenceStump) GetSplit() float64 {
+    return c.split
+}
+
+func (c *ConfidenceStump) GetLeftValue() float64 {
+    return c.leftValue
+}
+
+func (c *ConfidenceStump) GetRightValue() float64 {
+    return c.rightValue
+}
+
+// Makes the stump categorical, giving the right value to the samples holding one of the categories, which must be
+// sorted.
+func (c *ConfidenceStump) SetCategories(categories []float64) {
+    c.categories = categories
+}
+
+// Gets the categories getting the right value, nil when the stump is not categorical.
+func (c *ConfidenceStump) GetCategories() []float64 {
+    return c.categories
+}
+
+// Tells to which side of the split the samples missing the feature go.
+func (c *ConfidenceStump) SetMissingAbove(missingAbove bool) {
+    c.missingAbove = missingAbove
+}
+
+func (c *ConfidenceStump) GetMissingAbove() bool {
+    return c.missingAbove
+}
+
+func (c *ConfidenceStump) SetError(error float64) {
+    c.error = error
+}
+
+func (c *ConfidenceStump) GetError() float64 {
+    return c.error
+}
+
+func (c *ConfidenceStump) SetAlpha(alpha float64) {
+    c.alpha = alpha
+}
+
+func (c *ConfidenceStump) GetAlpha() float64 {
+    return c.alpha
+}
+
+func (c *ConfidenceStump) Kind() string {
+    return CONFIDENCE_STUMP_KIND
+}
+
+func (c *ConfidenceStump) ToMap() map[string]interface{} {
+    var stump = make(map[string]interface{})
+    stump["feature_number"] = c.featureNumber
+    stump["split"] = c.split
+    stump["left_value"] = c.leftValue
+    stump["right_value"] = c.rightValue
+    stump["missing_above"] = c.missingAbove
+    if c.categories != nil {
+        stump["category"] = c.categories
+    }
+    stump["weight"] = c.alpha
+    return stump
+}
+
+func (c *ConfidenceStump) String() string {
+    if c.categories != nil {
+        return fmt.Sprintf("featureNumber: %d, categories: %v, leftValue: %f, rightValue: %f, missingAbove: %t, error: %f, apha: %f", c.featureNumber, c.categories, c.leftValue, c.rightValue, c.missingAbove, c.error, c.alpha)
+    }
+    return fmt.Sprintf("featureNumber: %d, split: %f, leftValue: %f, rightValue: %f, missingAbove: %t, error: %f, apha: %f", c.featureNumber, c.split, c.leftValue, c.rightValue, c.missingAbove, c.error, c.alpha)
+}
+
+// Computes the confidence of a side of the split from its weighted class distribution.
+// Computes the following equation:
+// h = \frac{1}{2}\ln\left( \frac{W_{+} + \varepsilon}{W_{-} + \varepsilon}\right)
+//
+// where ε smooths the value when one of the classes is absent.
+func confidence(positiveWeight, negativeWeight, epsilon float64) float64 {
+    return 0.5 * math.Log((positiveWeight + epsilon) / (negativeWeight + epsilon))
+}
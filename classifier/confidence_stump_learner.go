@@ -0,0 +1,201 @@
+package classifier
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+    "math"
+)
+
+// Weak learner generating confidence-rated stumps for Real AdaBoost.
+//
+// For each split it partitions the samples in two sides and chooses the split minimizing the normalization factor:
+// Z_{t} = 2\sum_{j}\sqrt{W^{j}_{+}W^{j}_{-}}
+//
+// where W^{j}_{+} and W^{j}_{-} are the weights of the positive and negative samples on side j. The error of the
+// generated stumps holds Z_{t}. The samples missing the feature join the side giving the smaller Z_{t}.
+type ConfidenceStumpLearner struct {
+    featureSearcher
+}
+
+func NewConfidenceStumpLearner(options Options) *ConfidenceStumpLearner {
+    return &ConfidenceStumpLearner{featureSearcher: newFeatureSearcher(options.NumberOfWorkers, options.CategoricalFeatures)}
+}
+
+// Builds the sorted index for the given training set.
+func (w *ConfidenceStumpLearner) Prepare(samples [][]float64) {
+    w.buildIndex(samples)
+}
+
+// Builds the sorted columns for the given sparse training set.
+func (w *ConfidenceStumpLearner) PrepareSparse(samples *utils.SparseSamples) {
+    w.buildSparseIndex(samples)
+}
+
+// Learn confidence-rated weak classifier h_{t} using distribution D_{t}.
+// Ties are broken by the lowest feature number and then by the lowest split.
+func (w *ConfidenceStumpLearner) GenerateWeakClassifier(samples [][]float64, weights []float64) (WeakClassifier, error) {
+
+    if err := utils.ValidateSamples(samples, 1); err != nil {
+        return nil, err
+    }
+    numberOfSamples := len(samples)
+    numberOfFeatures := uint(len(samples[0]) - 1)
+
+    if !w.hasIndexFor(samples) {
+        w.Prepare(samples)
+    }
+
+    positiveWeight, negativeWeight := sumClassWeights(samples, weights)
+    epsilon := 1 / float64(numberOfSamples)
+    best := w.search(numberOfFeatures, func(featureNumber uint) searchCandidate {
+        if stump := w.findBestSplit(samples, weights, featureNumber, positiveWeight, negativeWeight, epsilon); stump != nil {
+            return stump
+        }
+        return nil
+    })
+
+    // Every feature is missing from every sample.
+    if best == nil {
+        return nil, utils.ErrDegenerateWeakClassifier
+    }
+    return best.(*ConfidenceStump), nil
+}
+
+// Finds the split of a feature minimizing Z_{t} with a single sweep over the sorted samples.
+func (w *ConfidenceStumpLearner) findBestSplit(samples [][]float64, weights []float64, featureNumber uint, positiveWeight, negativeWeight, epsilon float64) *ConfidenceStump {
+    label := func(row int) float64 {
+        return samples[row][len(samples[row]) - 1]
+    }
+    if column := w.categories[featureNumber]; column != nil {
+        return w.findBestCategoricalSplit(column, weights, label, featureNumber, positiveWeight, negativeWeight, epsilon)
+    }
+    var best *ConfidenceStump
+    present, missing := w.presentAndMissing(samples, featureNumber)
+    positiveMissing, negativeMissing := sumRowsClassWeights(missing, weights, label)
+    var positiveBelow, negativeBelow float64
+    for i, sampleIndex := range present {
+        sample := samples[sampleIndex]
+        if sample[len(sample) - 1] > 0 {
+            positiveBelow += weights[sampleIndex]
+        } else {
+            negativeBelow += weights[sampleIndex]
+        }
+        split := sample[featureNumber]
+
+        // Only evaluates the split after the last sample holding the same value.
+        if i + 1 < len(present) && samples[present[i + 1]][featureNumber] == split {
+            continue
+        }
+        positiveAbove := math.Max(positiveWeight - positiveBelow - positiveMissing, 0)
+        negativeAbove := math.Max(negativeWeight - negativeBelow - negativeMissing, 0)
+        if stump := newConfidenceSplitStump(featureNumber, split, positiveBelow, negativeBelow, positiveAbove, negativeAbove, positiveMissing, negativeMissing, epsilon); best == nil || lessError(stump.GetError(), best.GetError()) {
+            best = stump
+        }
+    }
+    return best
+}
+
+// Learn confidence-rated weak classifier h_{t} over sparse samples using distribution D_{t}.
+// The split search takes time proportional to the features other than 0.
+func (w *ConfidenceStumpLearner) GenerateSparseWeakClassifier(samples *utils.SparseSamples, weights []float64) (WeakClassifier, error) {
+
+    if err := samples.Validate(); err != nil {
+        return nil, err
+    }
+    if !w.hasSparseIndexFor(samples) {
+        w.PrepareSparse(samples)
+    }
+
+    positiveWeight, negativeWeight := sumSparseClassWeights(samples, weights)
+    epsilon := 1 / float64(samples.NumberOfSamples())
+    best := w.search(uint(samples.NumberOfFeatures), func(featureNumber uint) searchCandidate {
+        if stump := w.findBestSparseSplit(samples, weights, featureNumber, positiveWeight, negativeWeight, epsilon); stump != nil {
+            return stump
+        }
+        return nil
+    })
+
+    // Every feature is missing from every sample.
+    if best == nil {
+        return nil, utils.ErrDegenerateWeakClassifier
+    }
+    return best.(*ConfidenceStump), nil
+}
+
+// Finds the split of a feature of sparse samples minimizing Z_{t} with a single sweep over its sorted column.
+// The weights of the samples whose feature is 0 are the class weights minus the weights of the stored samples.
+func (w *ConfidenceStumpLearner) findBestSparseSplit(samples *utils.SparseSamples, weights []float64, featureNumber uint, positiveWeight, negativeWeight, epsilon float64) *ConfidenceStump {
+    label := func(row int) float64 {
+        return samples.Labels[row]
+    }
+    if column := w.categories[featureNumber]; column != nil {
+        return w.findBestCategoricalSplit(column, weights, label, featureNumber, positiveWeight, negativeWeight, epsilon)
+    }
+    var best *ConfidenceStump
+    storedPositive, storedNegative := w.sumStoredClassWeights(samples, weights, featureNumber)
+    _, _, missing := w.sparsePresentAndMissing(featureNumber)
+    positiveMissing, negativeMissing := sumRowsClassWeights(missing, weights, label)
+    var positiveBelow, negativeBelow float64
+    w.sweepSparse(featureNumber, func(row int, split float64, last bool) {
+        if row < 0 {
+            positiveBelow += math.Max(positiveWeight - storedPositive, 0)
+            negativeBelow += math.Max(negativeWeight - storedNegative, 0)
+        } else if samples.Labels[row] > 0 {
+            positiveBelow += weights[row]
+        } else {
+            negativeBelow += weights[row]
+        }
+        if !last {
+            return
+        }
+        positiveAbove := math.Max(positiveWeight - positiveBelow - positiveMissing, 0)
+        negativeAbove := math.Max(negativeWeight - negativeBelow - negativeMissing, 0)
+        if stump := newConfidenceSplitStump(featureNumber, split, positiveBelow, negativeBelow, positiveAbove, negativeAbove, positiveMissing, negativeMissing, epsilon); best == nil || lessError(stump.GetError(), best.GetError()) {
+            best = stump
+        }
+    })
+    return best
+}
+
+// Finds the subset of the categories of a categorical feature minimizing Z_{t}, the samples holding them getting the
+// right value. Only the splits of the categories ordered by their weighted positive rate are evaluated.
+func (w *ConfidenceStumpLearner) findBestCategoricalSplit(column *categoricalColumn, weights []float64, label func(row int) float64, featureNumber uint, positiveWeight, negativeWeight, epsilon float64) *ConfidenceStump {
+    var best *ConfidenceStump
+    bestPosition := 0
+    positive, negative, positiveMissing, negativeMissing := column.sumClassWeights(weights, label)
+    order := orderCategories(positiveRates(positive, negative))
+    var positiveBelow, negativeBelow float64
+    for i := 0; i + 1 < len(order); i++ {
+        positiveBelow += positive[order[i]]
+        negativeBelow += negative[order[i]]
+        positiveAbove := math.Max(positiveWeight - positiveBelow - positiveMissing, 0)
+        negativeAbove := math.Max(negativeWeight - negativeBelow - negativeMissing, 0)
+        if stump := newConfidenceSplitStump(featureNumber, 0, positiveBelow, negativeBelow, positiveAbove, negativeAbove, positiveMissing, negativeMissing, epsilon); best == nil || lessError(stump.GetError(), best.GetError()) {
+            best = stump
+            bestPosition = i + 1
+        }
+    }
+    if best != nil {
+        best.SetCategories(column.categoriesAt(order[bestPosition:]))
+    }
+    return best
+}
+
+// Creates the confidence-rated stump splitting a feature at split, given the class weights of the samples below and
+// above it and of the samples missing the feature. The missing samples join the side giving the smaller Z_{t}, the
+// left one on ties.
+func newConfidenceSplitStump(featureNumber uint, split, positiveBelow, negativeBelow, positiveAbove, negativeAbove, positiveMissing, negativeMissing, epsilon float64) *ConfidenceStump {
+    z := func(positiveLeft, negativeLeft, positiveRight, negativeRight float64) float64 {
+        return 2 * (math.Sqrt(positiveLeft * negativeLeft) + math.Sqrt(positiveRight * negativeRight))
+    }
+    zMissingBelow := z(positiveBelow + positiveMissing, negativeBelow + negativeMissing, positiveAbove, negativeAbove)
+    zMissingAbove := z(positiveBelow, negativeBelow, positiveAbove + positiveMissing, negativeAbove + negativeMissing)
+    if zMissingAbove < zMissingBelow {
+        stump := NewConfidenceStump(featureNumber, split, confidence(positiveBelow, negativeBelow, epsilon), confidence(positiveAbove + positiveMissing, negativeAbove + negativeMissing, epsilon))
+        stump.SetMissingAbove(true)
+        stump.SetError(zMissingAbove)
+        return stump
+    }
+    stump := NewConfidenceStump(featureNumber, split, confidence(positiveBelow + positiveMissing, negativeBelow + negativeMissing, epsilon), confidence(positiveAbove, negativeAbove, epsilon))
+    stump.SetError(zMissingBelow)
+    return stump
+}
@@ -0,0 +1,101 @@
+package classifier
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+    "math"
+    "math/rand"
+    "testing"
+)
+
+func TestConfidenceStumpLearnerSparseMatchesDense(t *testing.T) {
+    for seed, test := range sparseTests {
+        t.Run(test.name, func(t *testing.T) {
+            random := rand.New(rand.NewSource(int64(seed)))
+            samples := randomSamples(random, test.numberOfSamples, test.numberOfFeatures, test.levels, test.zeros, test.missing)
+            weights := randomWeights(random, len(samples))
+
+            dense, err := NewConfidenceStumpLearner(DefaultOptions()).GenerateWeakClassifier(samples, weights)
+            if err != nil {
+                t.Fatal(err)
+            }
+            sparse, err := NewConfidenceStumpLearner(DefaultOptions()).GenerateSparseWeakClassifier(utils.NewSparseSamplesFromDense(samples), weights)
+            if err != nil {
+                t.Fatal(err)
+            }
+            checkSameWeakClassifier(t, dense, sparse, samples)
+        })
+    }
+}
+
+// The weights above the split of a constant feature are the class weights minus the weights below it and missing it,
+// which rounding may leave slightly negative. They must not turn Z_{t} into NaN.
+func TestConfidenceStumpLearnerConstantFeatureWithMissingValues(t *testing.T) {
+    tests := []struct {
+        name    string
+        value   float64
+        missing float64
+    }{
+        {"few missing", 0.3, 0.1},
+        {"half missing", 1, 0.5},
+        {"mostly missing", 7, 0.9},
+        {"zeros", 0, 0.4},
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            random := rand.New(rand.NewSource(1))
+            for round := 0; round < 50; round++ {
+                samples := randomSamples(random, 40, 2, 5, 0, 0)
+                for _, sample := range samples {
+                    sample[1] = test.value
+                    if random.Float64() < test.missing {
+                        sample[1] = math.NaN()
+                    }
+                }
+                weights := randomWeights(random, len(samples))
+
+                dense, err := NewConfidenceStumpLearner(DefaultOptions()).GenerateWeakClassifier(samples, weights)
+                if err != nil {
+                    t.Fatal(err)
+                }
+                sparse, err := NewConfidenceStumpLearner(DefaultOptions()).GenerateSparseWeakClassifier(utils.NewSparseSamplesFromDense(samples), weights)
+                if err != nil {
+                    t.Fatal(err)
+                }
+                for _, weakClassifier := range []WeakClassifier{dense, sparse} {
+                    stump := weakClassifier.(*ConfidenceStump)
+                    if math.IsNaN(stump.GetError()) || math.IsNaN(stump.GetLeftValue()) || math.IsNaN(stump.GetRightValue()) {
+                        t.Fatalf("round %d: learned %v", round, stump)
+                    }
+                }
+                checkSameWeakClassifier(t, dense, sparse, samples)
+            }
+        })
+    }
+}
+
+func TestConfidenceStumpLearnerCategorical(t *testing.T) {
+    for seed, test := range categoricalTests {
+        t.Run(test.name, func(t *testing.T) {
+            random := rand.New(rand.NewSource(int64(seed)))
+            samples := randomSamples(random, test.numberOfSamples, test.numberOfFeatures, test.levels, test.zeros, test.missing)
+            weights := randomWeights(random, len(samples))
+            options := DefaultOptions()
+            for featureNumber := 0; featureNumber < test.numberOfFeatures; featureNumber++ {
+                options.CategoricalFeatures = append(options.CategoricalFeatures, uint(featureNumber))
+            }
+
+            dense, err := NewConfidenceStumpLearner(options).GenerateWeakClassifier(samples, weights)
+            if err != nil {
+                t.Fatal(err)
+            }
+            stump := dense.(*ConfidenceStump)
+            checkProperSubset(t, stump.GetCategories(), samples, stump.GetFeatureNumber())
+
+            sparse, err := NewConfidenceStumpLearner(options).GenerateSparseWeakClassifier(utils.NewSparseSamplesFromDense(samples), weights)
+            if err != nil {
+                t.Fatal(err)
+            }
+            checkSameWeakClassifier(t, dense, sparse, samples)
+        })
+    }
+}
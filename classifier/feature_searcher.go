@@ -0,0 +1,251 @@
+package classifier
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+    "runtime"
+    "sort"
+    "math"
+    "sync"
+)
+
+// Searches the best weak classifier of each feature using a pool of workers.
+//
+// It keeps, for each feature, the sample indexes sorted by the feature value. The index is built once per training
+// set so each round finds the optimal split of a feature with a single sweep over its sorted samples.
+//
+// For sparse samples it keeps instead the columns of the samples sorted by value. The samples whose feature is 0 are
+// not stored, and a sweep visits them at once, so it takes time proportional to the features other than 0.
+//
+// Samples missing a feature, holding NaN, are sorted last in both indexes and left out of the sweeps, so the learners
+// can send them to the side of the split that suits them best.
+//
+// The features declared categorical also get the category of each sample, so the learners can search subset splits
+// instead of thresholds.
+type featureSearcher struct {
+    sortedIndex         [][]int
+    sparseIndex         *utils.SparseColumns
+    categoricalFeatures []uint
+    categories          map[uint]*categoricalColumn
+    numberOfWorkers     int
+}
+
+func newFeatureSearcher(numberOfWorkers int, categoricalFeatures []uint) featureSearcher {
+    searcher := featureSearcher{categoricalFeatures: categoricalFeatures}
+    searcher.SetNumberOfWorkers(numberOfWorkers)
+    return searcher
+}
+
+// Sets how many goroutines search the features. Zero uses one per CPU.
+func (f *featureSearcher) SetNumberOfWorkers(numberOfWorkers int) {
+    if numberOfWorkers < 1 {
+        numberOfWorkers = runtime.NumCPU()
+    }
+    f.numberOfWorkers = numberOfWorkers
+}
+
+func (f *featureSearcher) GetNumberOfWorkers() int {
+    return f.numberOfWorkers
+}
+
+// Declares the features holding categories. It applies from the next built index.
+func (f *featureSearcher) SetCategoricalFeatures(categoricalFeatures []uint) {
+    f.categoricalFeatures = categoricalFeatures
+}
+
+func (f *featureSearcher) GetCategoricalFeatures() []uint {
+    return f.categoricalFeatures
+}
+
+// Builds the sorted index for the given training set, whose samples carry the label in the last position.
+func (f *featureSearcher) buildIndex(samples [][]float64) {
+    if len(samples) < 1 {
+        f.sortedIndex = nil
+        return
+    }
+    f.buildIndexForFeatures(samples, len(samples[0]) - 1)
+}
+
+// Builds the sorted index for the first numberOfFeatures positions of the samples.
+// For each feature, the sample indexes are sorted by the feature value, those missing it being last.
+func (f *featureSearcher) buildIndexForFeatures(samples [][]float64, numberOfFeatures int) {
+    f.sortedIndex = make([][]int, numberOfFeatures)
+    for featureNumber := 0; featureNumber < numberOfFeatures; featureNumber++ {
+        index := make([]int, len(samples))
+        for i := range index {
+            index[i] = i
+        }
+        sort.SliceStable(index, func(a, b int) bool {
+            return utils.LessWithNaNLast(samples[index[a]][featureNumber], samples[index[b]][featureNumber])
+        })
+        f.sortedIndex[featureNumber] = index
+    }
+    f.categories = make(map[uint]*categoricalColumn)
+    for _, featureNumber := range f.categoricalFeatures {
+        if int(featureNumber) >= numberOfFeatures {
+            continue
+        }
+        present, missing := f.presentAndMissing(samples, featureNumber)
+        values := make([]float64, len(present))
+        for i, row := range present {
+            values[i] = samples[row][featureNumber]
+        }
+        f.categories[featureNumber] = newCategoricalColumn(len(samples), present, values, missing)
+    }
+}
+
+// Tells if the index was built for a training set with the same shape of the given one.
+func (f *featureSearcher) hasIndexFor(samples [][]float64) bool {
+    return len(samples) > 0 && f.hasIndexForFeatures(samples, len(samples[0]) - 1)
+}
+
+func (f *featureSearcher) hasIndexForFeatures(samples [][]float64, numberOfFeatures int) bool {
+    return len(f.sortedIndex) == numberOfFeatures && numberOfFeatures > 0 && len(f.sortedIndex[0]) == len(samples)
+}
+
+// Splits the sorted index of a feature into the samples holding a value and those missing it.
+func (f *featureSearcher) presentAndMissing(samples [][]float64, featureNumber uint) (present, missing []int) {
+    index := f.sortedIndex[featureNumber]
+    n := sort.Search(len(index), func(i int) bool {
+        return math.IsNaN(samples[index[i]][featureNumber])
+    })
+    return index[:n], index[n:]
+}
+
+// Builds the sorted columns for the given sparse training set.
+func (f *featureSearcher) buildSparseIndex(samples *utils.SparseSamples) {
+    f.sparseIndex = samples.Columns()
+    f.sparseIndex.SortByValue()
+    f.categories = make(map[uint]*categoricalColumn)
+    for _, featureNumber := range f.categoricalFeatures {
+        if int(featureNumber) >= samples.NumberOfFeatures {
+            continue
+        }
+        rows, values, missing := f.sparsePresentAndMissing(featureNumber)
+        f.categories[featureNumber] = newCategoricalColumn(samples.NumberOfSamples(), rows, values, missing)
+    }
+}
+
+// Tells if the sparse index was built for a training set with the same shape of the given one.
+func (f *featureSearcher) hasSparseIndexFor(samples *utils.SparseSamples) bool {
+    return f.sparseIndex != nil && f.sparseIndex.NumberOfSamples == samples.NumberOfSamples() && f.sparseIndex.NumberOfFeatures() == samples.NumberOfFeatures && len(f.sparseIndex.Rows) == len(samples.Indices)
+}
+
+// Gets the column of a feature of the sparse index without the samples missing it, and the rows of those samples.
+func (f *featureSearcher) sparsePresentAndMissing(featureNumber uint) (rows []int, values []float64, missing []int) {
+    rows, values = f.sparseIndex.Column(int(featureNumber))
+    n := sort.Search(len(values), func(i int) bool {
+        return math.IsNaN(values[i])
+    })
+    return rows[:n], values[:n], rows[n:]
+}
+
+// Visits the samples of a feature of the sparse index in increasing order of value. The samples whose feature is 0
+// are visited at once, as row -1, between the negative and the positive values. last tells if no further sample holds
+// the visited value, so a split can be evaluated. The samples missing the feature are not visited.
+func (f *featureSearcher) sweepSparse(featureNumber uint, visit func(row int, value float64, last bool)) {
+    stored, _ := f.sparseIndex.Column(int(featureNumber))
+    hasZeros := len(stored) < f.sparseIndex.NumberOfSamples
+    rows, values, _ := f.sparsePresentAndMissing(featureNumber)
+    zeros := sort.SearchFloat64s(values, 0)
+    for i := 0; i <= len(rows); i++ {
+        if i == zeros && hasZeros {
+            visit(-1, 0, i == len(rows) || values[i] != 0)
+        }
+        if i == len(rows) {
+            break
+        }
+        visit(rows[i], values[i], i + 1 == len(rows) || values[i + 1] != values[i])
+    }
+}
+
+// Anything the searcher can compare, like weak classifiers.
+type searchCandidate interface {
+    GetError() float64
+}
+
+// Calls find for every feature, distributing the features across the workers, and returns the candidate with
+// minimum error. find returns nil when the feature has no candidate.
+//
+// Reduces in feature order, so the result does not depend on the scheduling of the workers.
+func (f *featureSearcher) search(numberOfFeatures uint, find func(featureNumber uint) searchCandidate) searchCandidate {
+    candidates := make([]searchCandidate, numberOfFeatures)
+    features := make(chan uint)
+    var wg sync.WaitGroup
+    for i := 0; i < f.numberOfWorkers; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for featureNumber := range features {
+                candidates[featureNumber] = find(featureNumber)
+            }
+        }()
+    }
+    for featureNumber := uint(0); featureNumber < numberOfFeatures; featureNumber++ {
+        features <- featureNumber
+    }
+    close(features)
+    wg.Wait()
+
+    // Candidates whose error is NaN are never chosen.
+    var best searchCandidate
+    for _, candidate := range candidates {
+        if candidate != nil && !math.IsNaN(candidate.GetError()) && (best == nil || candidate.GetError() < best.GetError()) {
+            best = candidate
+        }
+    }
+    return best
+}
+
+// Tells if an error is less than the best one so far. A NaN error is never less, and any other is less than a NaN one.
+func lessError(error, bestError float64) bool {
+    return !math.IsNaN(error) && (math.IsNaN(bestError) || error < bestError)
+}
+
+// Sums the weights of each class of sparse samples.
+func sumSparseClassWeights(samples *utils.SparseSamples, weights []float64) (positiveWeight, negativeWeight float64) {
+    for i, label := range samples.Labels {
+        if label > 0 {
+            positiveWeight += weights[i]
+        } else {
+            negativeWeight += weights[i]
+        }
+    }
+    return
+}
+
+// Sums the weights of each class of the samples whose feature is not 0 in the sparse index.
+func (f *featureSearcher) sumStoredClassWeights(samples *utils.SparseSamples, weights []float64, featureNumber uint) (positiveWeight, negativeWeight float64) {
+    rows, _ := f.sparseIndex.Column(int(featureNumber))
+    for _, row := range rows {
+        if samples.Labels[row] > 0 {
+            positiveWeight += weights[row]
+        } else {
+            negativeWeight += weights[row]
+        }
+    }
+    return
+}
+
+// Sums the weights of each class of the given rows, whose labels are found by label.
+func sumRowsClassWeights(rows []int, weights []float64, label func(row int) float64) (positiveWeight, negativeWeight float64) {
+    for _, row := range rows {
+        if label(row) > 0 {
+            positiveWeight += weights[row]
+        } else {
+            negativeWeight += weights[row]
+        }
+    }
+    return
+}
+
+// Sums the weights of each class.
+func sumClassWeights(samples [][]float64, weights []float64) (positiveWeight, negativeWeight float64) {
+    for i, sample := range samples {
+        if sample[len(sample) - 1] > 0 {
+            positiveWeight += weights[i]
+        } else {
+            negativeWeight += weights[i]
+        }
+    }
+    return
+}
@@ -0,0 +1,57 @@
+package classifier
+
+import (
+    "math"
+)
+
+// Working responses are clipped to keep samples with probabilities close to 0 or 1 from dominating the fit.
+const MAX_WORKING_RESPONSE = 4.0
+
+// LogitBoost starts with F(x_{i}) = 0, that is, p(x_{i}) = 1/2 for every sample.
+// The weights are computed from the scores each round.
+func (c *AdaBoost) initializeScores(samples trainingSet) {
+    c.scores = make([]float64, samples.size())
+    c.weights = make([]float64, samples.size())
+}
+
+// Computes the working responses and weights of LogitBoost from the current scores.
+//
+// Computes the following equations:
+// p(x_{i}) = \frac{1}{1 + e^{-2F(x_{i})}}
+// z_{i} = \frac{y^{*}_{i} - p(x_{i})}{p(x_{i})(1 - p(x_{i}))}
+// w_{i} = p(x_{i})(1 - p(x_{i}))
+//
+// where y^{*}_{i} = (y_{i} + 1) / 2 maps the label to {0, 1}. The weights are scaled by the weights of the samples,
+// when given.
+func (c *AdaBoost) computeWorkingResponses(samples trainingSet) []float64 {
+    targets := make([]float64, samples.size())
+    sampleWeights := samples.sampleWeights()
+    sum := 0.0
+    for i := range targets {
+        y := (samples.label(i) + 1) / 2
+        p := 1 / (1 + math.Exp(-2 * c.scores[i]))
+        weight := math.Max(p * (1 - p), 1e-10)
+        targets[i] = math.Max(-MAX_WORKING_RESPONSE, math.Min(MAX_WORKING_RESPONSE, (y - p) / weight))
+        if sampleWeights != nil {
+            weight *= sampleWeights[i]
+        }
+        c.weights[i] = weight
+        sum += weight
+    }
+    for i := range c.weights {
+        c.weights[i] /= sum
+    }
+    return targets
+}
+
+// Fits f_{t} to the working responses by weighted least squares.
+// F(x) is updated by \frac{\nu}{2}f_{t}(x), so the regressor's alpha is half the learning rate ν.
+func (c *AdaBoost) generateLogitRegressor(samples trainingSet) (WeakClassifier, error) {
+    targets := c.computeWorkingResponses(samples)
+    regressor, err := samples.generateRegressor(c.weakLearner, targets, c.weights)
+    if err != nil {
+        return nil, err
+    }
+    regressor.SetAlpha(0.5 * c.options.LearningRate)
+    return regressor, nil
+}
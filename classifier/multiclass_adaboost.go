@@ -0,0 +1,232 @@
+package classifier
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+    "fmt"
+    "math"
+    "sort"
+)
+
+// Variant of the multiclass boosting algorithm.
+type MultiClassMode int
+
+const (
+
+    // Stagewise Additive Modeling using a Multi-class Exponential loss: each weak classifier votes for a class
+    // weighted by α_{t}.
+    SAMME MultiClassMode = iota
+
+    // SAMME with real-valued weak classifiers built from their estimated class probabilities.
+    SAMME_R
+)
+
+var multiClassModeNames = map[MultiClassMode]string{
+    SAMME: "samme",
+    SAMME_R: "samme.r",
+}
+
+func (m MultiClassMode) String() string {
+    if name, ok := multiClassModeNames[m]; ok {
+        return name
+    }
+    return fmt.Sprintf("MultiClassMode(%d)", int(m))
+}
+
+// Parses the name of a multiclass mode, as persisted with the model.
+func ParseMultiClassMode(name string) (MultiClassMode, bool) {
+    for mode, modeName := range multiClassModeNames {
+        if modeName == name {
+            return mode, true
+        }
+    }
+    return SAMME, false
+}
+
+// Probabilities are clipped to keep their logarithm finite.
+const MIN_CLASS_PROBABILITY = 1e-10
+
+// AdaBoost for K classes.
+//
+// Samples carry their class, any integer, in the last position. The classes seen in the training set are kept
+// sorted in Classes and the weak classifiers refer to them by index.
+type MultiClassAdaBoost struct {
+    WeakClassifiers     []*MultiClassStump
+    Classes             []int
+    weakLearner         *MultiClassStumpLearner
+    mode                MultiClassMode
+    numberOfClassifiers uint
+    learningRate        float64
+    weights             []float64
+}
+
+func NewMultiClassAdaBoost(mode MultiClassMode, options Options) MultiClassAdaBoost {
+    return MultiClassAdaBoost{
+        WeakClassifiers: []*MultiClassStump{},
+        weakLearner: NewMultiClassStumpLearner(options),
+        mode: mode,
+        numberOfClassifiers: options.NumberOfClassifiers,
+        learningRate: options.LearningRate,
+        weights: []float64{},
+    }
+}
+
+func (c *MultiClassAdaBoost) GetMode() MultiClassMode {
+    return c.mode
+}
+
+// Finds the sorted list of classes and the index of the class of each sample.
+// Fails when a class is not an integer.
+func (c *MultiClassAdaBoost) indexClasses(samples [][]float64) ([]int, error) {
+    seen := make(map[int]bool)
+    c.Classes = []int{}
+    for i, sample := range samples {
+        y := sample[len(sample) - 1]
+        if y != math.Trunc(y) || math.IsInf(y, 0) {
+            return nil, &utils.RowError{Row: i, Column: len(sample) - 1, Err: fmt.Errorf("%w: %v is not an integer", utils.ErrInvalidLabel, y)}
+        }
+        label := int(y)
+        if !seen[label] {
+            seen[label] = true
+            c.Classes = append(c.Classes, label)
+        }
+    }
+    sort.Ints(c.Classes)
+    classIndexes := make([]int, len(samples))
+    for i, sample := range samples {
+        classIndexes[i] = c.ClassIndex(int(sample[len(sample) - 1]))
+    }
+    return classIndexes, nil
+}
+
+// Gets the index of a class in Classes, or -1 when the class is unknown.
+func (c *MultiClassAdaBoost) ClassIndex(label int) int {
+    i := sort.SearchInts(c.Classes, label)
+    if i < len(c.Classes) && c.Classes[i] == label {
+        return i
+    }
+    return -1
+}
+
+// Learn!
+//
+// Stops early when a weak classifier is no better than random guessing, that is, when \epsilon_{t} \geq 1 - 1/K.
+func (c *MultiClassAdaBoost) Train(samples [][]float64) error {
+    if err := utils.ValidateSamples(samples, 1); err != nil {
+        return err
+    }
+    classIndexes, err := c.indexClasses(samples)
+    if err != nil {
+        return err
+    }
+    numberOfClasses := len(c.Classes)
+    if numberOfClasses < 2 {
+        return fmt.Errorf("%w: at least two classes are needed", utils.ErrInvalidLabel)
+    }
+
+    c.weights = make([]float64, len(samples))
+    for i := range c.weights {
+        c.weights[i] = 1 / float64(len(samples))
+    }
+
+    c.weakLearner.Prepare(samples)
+
+    // Build T classifiers.
+    for i := uint(0); i < c.numberOfClassifiers; i++ {
+        weakClassifier, err := c.weakLearner.GenerateWeakClassifier(samples, classIndexes, numberOfClasses, c.weights)
+        if err != nil {
+            return err
+        }
+        if weakClassifier.GetError() >= 1 - 1 / float64(numberOfClasses) {
+            break
+        }
+        if c.mode == SAMME_R {
+            c.updateWeightsReal(weakClassifier, samples, classIndexes)
+        } else {
+            c.computeAlpha(weakClassifier, numberOfClasses)
+            c.updateWeights(weakClassifier, samples, classIndexes)
+        }
+        c.WeakClassifiers = append(c.WeakClassifiers, weakClassifier)
+    }
+    return nil
+}
+
+// Computes the following equation, shrunk by the learning rate ν:
+// \alpha_{t} = \nu\left(\ln\left( \frac{1 - \epsilon_{t}}{\epsilon_{t}}\right) + \ln(K - 1)\right)
+func (c *MultiClassAdaBoost) computeAlpha(weakClassifier *MultiClassStump, numberOfClasses int) {
+    error := math.Max(weakClassifier.GetError(), MIN_CLASS_PROBABILITY)
+    weakClassifier.SetAlpha(c.learningRate * (math.Log((1 - error) / error) + math.Log(float64(numberOfClasses - 1))))
+}
+
+// Update the distribution of SAMME, increasing the weight of the wrongly classified samples:
+// D_{t+1}(i)=\frac{D_{t}(i)e^{\alpha_{t}[y_{i} \neq h_{t}(x_{i})]}}{Z_{t}}
+func (c *MultiClassAdaBoost) updateWeights(weakClassifier *MultiClassStump, samples [][]float64, classIndexes []int) {
+    for i, sample := range samples {
+        if weakClassifier.Classify(sample) != classIndexes[i] {
+            c.weights[i] *= math.Exp(weakClassifier.GetAlpha())
+        }
+    }
+    c.normalizeWeights()
+}
+
+// Update the distribution of SAMME.R:
+// D_{t+1}(i)=\frac{D_{t}(i)e^{-\nu\frac{K-1}{K}y_{i}^{T}\ln p_{t}(x_{i})}}{Z_{t}}
+//
+// where y_{i,k} is 1 for the class of the sample and -1/(K-1) for the others. The alpha of the weak classifier is the
+// learning rate ν, which also shrinks its scores.
+func (c *MultiClassAdaBoost) updateWeightsReal(weakClassifier *MultiClassStump, samples [][]float64, classIndexes []int) {
+    weakClassifier.SetAlpha(c.learningRate)
+    numberOfClasses := float64(len(c.Classes))
+    for i, sample := range samples {
+        exponent := 0.0
+        for k, p := range weakClassifier.Probabilities(sample) {
+            y := -1 / (numberOfClasses - 1)
+            if k == classIndexes[i] {
+                y = 1
+            }
+            exponent += y * math.Log(math.Max(p, MIN_CLASS_PROBABILITY))
+        }
+        c.weights[i] *= math.Exp(-weakClassifier.GetAlpha() * (numberOfClasses - 1) / numberOfClasses * exponent)
+    }
+    c.normalizeWeights()
+}
+
+func (c *MultiClassAdaBoost) normalizeWeights() {
+    sum := 0.0
+    for _, weight := range c.weights {
+        sum += weight
+    }
+    for i := range c.weights {
+        c.weights[i] /= sum
+    }
+}
+
+// Computes the score of each class.
+//
+// SAMME: f_{k}(x) = \sum_{t=1}^{T}\alpha_{t}[h_{t}(x) = k]
+// SAMME.R: f_{k}(x) = \sum_{t=1}^{T}\nu(K - 1)\left(\ln p^{t}_{k}(x) - \frac{1}{K}\sum_{j}\ln p^{t}_{j}(x)\right)
+func (c *MultiClassAdaBoost) Scores(sample []float64) []float64 {
+    scores := make([]float64, len(c.Classes))
+    numberOfClasses := float64(len(c.Classes))
+    for _, weakClassifier := range c.WeakClassifiers {
+        if c.mode == SAMME_R {
+            probabilities := weakClassifier.Probabilities(sample)
+            meanLog := 0.0
+            for _, p := range probabilities {
+                meanLog += math.Log(math.Max(p, MIN_CLASS_PROBABILITY)) / numberOfClasses
+            }
+            for k, p := range probabilities {
+                scores[k] += weakClassifier.GetAlpha() * (numberOfClasses - 1) * (math.Log(math.Max(p, MIN_CLASS_PROBABILITY)) - meanLog)
+            }
+        } else {
+            scores[weakClassifier.Classify(sample)] += weakClassifier.GetAlpha()
+        }
+    }
+    return scores
+}
+
+// H(x)=\underset{k}{\operatorname{arg\,max}}\,f_{k}(x)
+//
+// Returns the class label, not its index.
+func (c *MultiClassAdaBoost) Classify(sample []float64) int {
+    return c.Classes[argMax(c.Scores(sample))]
+}
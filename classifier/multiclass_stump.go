@@ -0,0 +1,125 @@
+package classifier
+
+import "fmt"
+
+const MULTICLASS_STUMP_KIND = "multiclass_stump"
+
+// Single threshold weak classifier for K classes.
+//
+// Each side of the split predicts the class with the highest weight among its training samples, and also keeps the
+// estimated probability of each class, used by SAMME.R. Classes are referred to by their index in the model's
+// class list. Samples missing the feature, NaN, fall on the left side, or on the right one when missingAbove is set.
+type MultiClassStump struct {
+    featureNumber      uint
+    split              float64
+    leftClass          int
+    rightClass         int
+    leftProbabilities  []float64
+    rightProbabilities []float64
+    missingAbove       bool
+    error              float64
+    alpha              float64
+}
+
+func NewMultiClassStump(featureNumber uint, split float64, leftProbabilities, rightProbabilities []float64) *MultiClassStump {
+    return &MultiClassStump{
+        featureNumber: featureNumber,
+        split: split,
+        leftClass: argMax(leftProbabilities),
+        rightClass: argMax(rightProbabilities),
+        leftProbabilities: leftProbabilities,
+        rightProbabilities: rightProbabilities,
+    }
+}
+
+// Predicts the class index of the sample.
+func (c *MultiClassStump) Classify(sample []float64) int {
+    if goesAbove(sample[c.featureNumber], c.split, nil, c.missingAbove) {
+        return c.rightClass
+    }
+    return c.leftClass
+}
+
+// Estimates the probability of each class for the sample.
+func (c *MultiClassStump) Probabilities(sample []float64) []float64 {
+    if goesAbove(sample[c.featureNumber], c.split, nil, c.missingAbove) {
+        return c.rightProbabilities
+    }
+    return c.leftProbabilities
+}
+
+func (c *MultiClassStump) GetFeatureNumber() uint {
+    return c.featureNumber
+}
+
+func (c *MultiClassStump) GetFeatureNumbers() []uint {
+    return []uint{c.featureNumber}
+}
+
+func (c *MultiClassStump) GetSplit() float64 {
+    return c.split
+}
+
+func (c *MultiClassStump) GetLeftProbabilities() []float64 {
+    return c.leftProbabilities
+}
+
+func (c *MultiClassStump) GetRightProbabilities() []float64 {
+    return c.rightProbabilities
+}
+
+func (c *MultiClassStump) SetMissingAbove(missingAbove bool) {
+    c.missingAbove = missingAbove
+}
+
+func (c *MultiClassStump) GetMissingAbove() bool {
+    return c.missingAbove
+}
+
+func (c *MultiClassStump) SetError(error float64) {
+    c.error = error
+}
+
+func (c *MultiClassStump) GetError() float64 {
+    return c.error
+}
+
+func (c *MultiClassStump) SetAlpha(alpha float64) {
+    c.alpha = alpha
+}
+
+func (c *MultiClassStump) GetAlpha() float64 {
+    return c.alpha
+}
+
+func (c *MultiClassStump) Kind() string {
+    return MULTICLASS_STUMP_KIND
+}
+
+func (c *MultiClassStump) ToMap() map[string]interface{} {
+    var stump = make(map[string]interface{})
+    stump["feature_number"] = c.featureNumber
+    stump["split"] = c.split
+    stump["left_class"] = c.leftClass
+    stump["right_class"] = c.rightClass
+    stump["left_probabilities"] = c.leftProbabilities
+    stump["right_probabilities"] = c.rightProbabilities
+    stump["missing_above"] = c.missingAbove
+    stump["weight"] = c.alpha
+    return stump
+}
+
+func (c *MultiClassStump) String() string {
+    return fmt.Sprintf("featureNumber: %d, split: %f, leftClass: %d, rightClass: %d, missingAbove: %t, error: %f, apha: %f", c.featureNumber, c.split, c.leftClass, c.rightClass, c.missingAbove, c.error, c.alpha)
+}
+
+// Gets the index of the maximum value, the first one on ties.
+func argMax(values []float64) int {
+    best := 0
+    for i, value := range values {
+        if value > values[best] {
+            best = i
+        }
+    }
+    return best
+}
@@ -0,0 +1,127 @@
+package classifier
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+)
+
+// Weak learner generating stumps for K classes.
+//
+// For each split, each side predicts its class with the highest weight, and the split minimizing the weighted
+// misclassification error is chosen:
+// \epsilon = W - \max_{k}W^{left}_{k} - \max_{k}W^{right}_{k}
+type MultiClassStumpLearner struct {
+    featureSearcher
+}
+
+func NewMultiClassStumpLearner(options Options) *MultiClassStumpLearner {
+    return &MultiClassStumpLearner{featureSearcher: newFeatureSearcher(options.NumberOfWorkers, nil)}
+}
+
+// Builds the sorted index for the given training set.
+func (w *MultiClassStumpLearner) Prepare(samples [][]float64) {
+    w.buildIndex(samples)
+}
+
+// Learn weak classifier h_{t} using distribution D_{t}.
+// classIndexes holds the index of the class of each sample, in [0, numberOfClasses).
+// Ties are broken by the lowest feature number and then by the lowest split.
+func (w *MultiClassStumpLearner) GenerateWeakClassifier(samples [][]float64, classIndexes []int, numberOfClasses int, weights []float64) (*MultiClassStump, error) {
+
+    if err := utils.ValidateSamples(samples, 1); err != nil {
+        return nil, err
+    }
+    numberOfSamples := len(samples)
+    numberOfFeatures := uint(len(samples[0]) - 1)
+
+    if !w.hasIndexFor(samples) {
+        w.Prepare(samples)
+    }
+
+    total := make([]float64, numberOfClasses)
+    for i, classIndex := range classIndexes {
+        total[classIndex] += weights[i]
+    }
+    epsilon := 1 / float64(numberOfSamples)
+    best := w.search(numberOfFeatures, func(featureNumber uint) searchCandidate {
+        if stump := w.findBestSplit(samples, classIndexes, weights, featureNumber, total, epsilon); stump != nil {
+            return stump
+        }
+        return nil
+    })
+
+    // Every feature is missing from every sample.
+    if best == nil {
+        return nil, utils.ErrDegenerateWeakClassifier
+    }
+    return best.(*MultiClassStump), nil
+}
+
+// Finds the split of a feature with minimum error with a single sweep over the sorted samples. The samples missing
+// the feature join the side giving the smaller error, the left one on ties.
+func (w *MultiClassStumpLearner) findBestSplit(samples [][]float64, classIndexes []int, weights []float64, featureNumber uint, total []float64, epsilon float64) *MultiClassStump {
+    var best *MultiClassStump
+    bestError := 0.0
+    totalWeight := sum(total)
+    index, missing := w.presentAndMissing(samples, featureNumber)
+    missingWeights := make([]float64, len(total))
+    below := make([]float64, len(total))
+    above := make([]float64, len(total))
+    for _, sampleIndex := range missing {
+        missingWeights[classIndexes[sampleIndex]] += weights[sampleIndex]
+    }
+    for i, sampleIndex := range index {
+        below[classIndexes[sampleIndex]] += weights[sampleIndex]
+        split := samples[sampleIndex][featureNumber]
+
+        // Only evaluates the split after the last sample holding the same value.
+        if i + 1 < len(index) && samples[index[i + 1]][featureNumber] == split {
+            continue
+        }
+        for k := range total {
+            above[k] = total[k] - below[k] - missingWeights[k]
+        }
+        left, right := addWeights(below, missingWeights), above
+        missingAbove := false
+        error := totalWeight - left[argMax(left)] - right[argMax(right)]
+        if len(missing) > 0 {
+            aboveWithMissing := addWeights(above, missingWeights)
+            if errorMissingAbove := totalWeight - below[argMax(below)] - aboveWithMissing[argMax(aboveWithMissing)]; errorMissingAbove < error {
+                left, right, missingAbove, error = below, aboveWithMissing, true, errorMissingAbove
+            }
+        }
+        if best == nil || error < bestError {
+            best = NewMultiClassStump(featureNumber, split, classProbabilities(left, epsilon), classProbabilities(right, epsilon))
+            best.SetMissingAbove(missingAbove)
+            best.SetError(error)
+            bestError = error
+        }
+    }
+    return best
+}
+
+// Gets the sum of two vectors of class weights.
+func addWeights(a, b []float64) []float64 {
+    weights := make([]float64, len(a))
+    for k := range weights {
+        weights[k] = a[k] + b[k]
+    }
+    return weights
+}
+
+// Estimates the probability of each class from their weights.
+// ε smooths the estimate so no class gets a zero probability.
+func classProbabilities(classWeights []float64, epsilon float64) []float64 {
+    probabilities := make([]float64, len(classWeights))
+    denominator := sum(classWeights) + epsilon * float64(len(classWeights))
+    for k, weight := range classWeights {
+        probabilities[k] = (weight + epsilon) / denominator
+    }
+    return probabilities
+}
+
+func sum(values []float64) (total float64) {
+    for _, value := range values {
+        total += value
+    }
+    return
+}
@@ -0,0 +1,115 @@
+package classifier
+
+import (
+    "fmt"
+    "math"
+)
+
+const MULTILABEL_STUMP_KIND = "multilabel_stump"
+
+// Single threshold weak classifier shared by L labels, as used by AdaBoost.MH.
+//
+// The split gives φ(x) = 1 for samples above it and -1 otherwise, and each label l has its own vote v_{l}, 1 or -1,
+// so the classifier outputs h(x, l) = \alpha v_{l}φ(x). Samples missing the feature, NaN, get φ(x) = -1, or 1 when
+// missingAbove is set.
+type MultiLabelStump struct {
+    featureNumber uint
+    split         float64
+    votes         []int
+    missingAbove  bool
+    error         float64
+    alpha         float64
+}
+
+func NewMultiLabelStump(featureNumber uint, split float64, votes []int) *MultiLabelStump {
+    return &MultiLabelStump{featureNumber: featureNumber, split: split, votes: votes}
+}
+
+// Set weight α_{t} based on the weighted Hamming error.
+// Computes the following equation:
+// \alpha_{t} = \frac{1}{2}\ln\left( \frac{1 - \epsilon_{t}(h_{t})}{\epsilon_{t}(h_{t})}\right)
+func (c *MultiLabelStump) ComputeAlpha() {
+    error := math.Max(c.error, MIN_CLASS_PROBABILITY)
+    c.alpha = 0.5 * math.Log((1.0 - error) / error)
+}
+
+// φ(x), the side of the split the sample falls into.
+func (c *MultiLabelStump) side(sample []float64) int {
+    if goesAbove(sample[c.featureNumber], c.split, nil, c.missingAbove) {
+        return 1
+    }
+    return -1
+}
+
+// Predicts each label, -1 or 1, of the sample.
+func (c *MultiLabelStump) Classify(sample []float64) []int {
+    side := c.side(sample)
+    labels := make([]int, len(c.votes))
+    for l, vote := range c.votes {
+        labels[l] = vote * side
+    }
+    return labels
+}
+
+// Predicts the label l of the sample weighted by the classifier's alpha: α_{t}v_{l}φ(x).
+func (c *MultiLabelStump) ClassifyLabelWithAlpha(sample []float64, l int) float64 {
+    return float64(c.votes[l] * c.side(sample)) * c.alpha
+}
+
+func (c *MultiLabelStump) GetFeatureNumber() uint {
+    return c.featureNumber
+}
+
+func (c *MultiLabelStump) GetFeatureNumbers() []uint {
+    return []uint{c.featureNumber}
+}
+
+func (c *MultiLabelStump) GetSplit() float64 {
+    return c.split
+}
+
+func (c *MultiLabelStump) GetVotes() []int {
+    return c.votes
+}
+
+func (c *MultiLabelStump) SetMissingAbove(missingAbove bool) {
+    c.missingAbove = missingAbove
+}
+
+func (c *MultiLabelStump) GetMissingAbove() bool {
+    return c.missingAbove
+}
+
+func (c *MultiLabelStump) SetError(error float64) {
+    c.error = error
+}
+
+func (c *MultiLabelStump) GetError() float64 {
+    return c.error
+}
+
+func (c *MultiLabelStump) SetAlpha(alpha float64) {
+    c.alpha = alpha
+}
+
+func (c *MultiLabelStump) GetAlpha() float64 {
+    return c.alpha
+}
+
+func (c *MultiLabelStump) Kind() string {
+    return MULTILABEL_STUMP_KIND
+}
+
+func (c *MultiLabelStump) ToMap() map[string]interface{} {
+    var stump = make(map[string]interface{})
+    stump["feature_number"] = c.featureNumber
+    stump["split"] = c.split
+    stump["votes"] = c.votes
+    stump["missing_above"] = c.missingAbove
+    stump["weight"] = c.alpha
+    return stump
+}
+
+func (c *MultiLabelStump) String() string {
+    return fmt.Sprintf("featureNumber: %d, split: %f, votes: %v, missingAbove: %t, error: %f, apha: %f", c.featureNumber, c.split, c.votes, c.missingAbove, c.error, c.alpha)
+}
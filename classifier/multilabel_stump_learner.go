@@ -0,0 +1,123 @@
+package classifier
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+    "math"
+)
+
+// Weak learner generating multi-label stumps for AdaBoost.MH.
+//
+// For a split s, the edge of label l is:
+// \gamma_{l} = \sum_{i}w_{i,l}y_{i,l}φ_{s}(x_{i}) = T_{l} - 2\sum_{x_{i} \leq s}w_{i,l}y_{i,l}
+//
+// where T_{l} = \sum_{i}w_{i,l}y_{i,l}. Each vote takes the sign of its edge, so the stump's edge is
+// \gamma = \sum_{l}|\gamma_{l}| and its weighted Hamming error is (1 - \gamma) / 2, which the chosen split minimizes.
+type MultiLabelStumpLearner struct {
+    featureSearcher
+}
+
+func NewMultiLabelStumpLearner(options Options) *MultiLabelStumpLearner {
+    return &MultiLabelStumpLearner{featureSearcher: newFeatureSearcher(options.NumberOfWorkers, nil)}
+}
+
+// Builds the sorted index for the first numberOfFeatures positions of the samples.
+func (w *MultiLabelStumpLearner) Prepare(samples [][]float64, numberOfFeatures int) {
+    w.buildIndexForFeatures(samples, numberOfFeatures)
+}
+
+// Learn weak classifier h_{t} using distribution D_{t} over the (sample, label) pairs.
+// labels[i][l] and weights[i][l] hold the label, -1 or 1, and the weight of the pair.
+// Ties are broken by the lowest feature number and then by the lowest split.
+func (w *MultiLabelStumpLearner) GenerateWeakClassifier(samples [][]float64, numberOfFeatures uint, labels [][]float64, weights [][]float64) (*MultiLabelStump, error) {
+
+    if len(samples) < 1 {
+        return nil, utils.ErrEmptyDataset
+    }
+    if err := utils.ValidateSamples(samples, len(samples[0]) - int(numberOfFeatures)); err != nil {
+        return nil, err
+    }
+
+    if !w.hasIndexForFeatures(samples, int(numberOfFeatures)) {
+        w.Prepare(samples, int(numberOfFeatures))
+    }
+
+    numberOfLabels := len(labels[0])
+    totalWeight := 0.0
+    total := make([]float64, numberOfLabels)
+    for i := range samples {
+        for l := 0; l < numberOfLabels; l++ {
+            total[l] += weights[i][l] * labels[i][l]
+            totalWeight += weights[i][l]
+        }
+    }
+    best := w.search(numberOfFeatures, func(featureNumber uint) searchCandidate {
+        if stump := w.findBestSplit(samples, labels, weights, featureNumber, total, totalWeight); stump != nil {
+            return stump
+        }
+        return nil
+    })
+
+    // Every feature is missing from every sample.
+    if best == nil {
+        return nil, utils.ErrDegenerateWeakClassifier
+    }
+    return best.(*MultiLabelStump), nil
+}
+
+// Finds the split of a feature with maximum edge with a single sweep over the sorted samples. The samples missing
+// the feature join the side giving the larger edge, the one below the split on ties.
+func (w *MultiLabelStumpLearner) findBestSplit(samples [][]float64, labels [][]float64, weights [][]float64, featureNumber uint, total []float64, totalWeight float64) *MultiLabelStump {
+    var best *MultiLabelStump
+    index, missing := w.presentAndMissing(samples, featureNumber)
+    below := make([]float64, len(total))
+    missingEdges := make([]float64, len(total))
+    for _, sampleIndex := range missing {
+        for l := range total {
+            missingEdges[l] += weights[sampleIndex][l] * labels[sampleIndex][l]
+        }
+    }
+    for i, sampleIndex := range index {
+        for l := range total {
+            below[l] += weights[sampleIndex][l] * labels[sampleIndex][l]
+        }
+        split := samples[sampleIndex][featureNumber]
+
+        // Only evaluates the split after the last sample holding the same value.
+        if i + 1 < len(index) && samples[index[i + 1]][featureNumber] == split {
+            continue
+        }
+        votes, edge := stumpVotes(total, below, missingEdges)
+        missingAbove := false
+        if len(missing) > 0 {
+            if votesMissingAbove, edgeMissingAbove := stumpVotes(total, below, nil); edgeMissingAbove > edge {
+                votes, edge, missingAbove = votesMissingAbove, edgeMissingAbove, true
+            }
+        }
+        error := (1 - edge / totalWeight) / 2
+        if best == nil || error < best.GetError() {
+            best = NewMultiLabelStump(featureNumber, split, votes)
+            best.SetMissingAbove(missingAbove)
+            best.SetError(error)
+        }
+    }
+    return best
+}
+
+// Gets the vote of each label and the edge of a split given the sums of w_{i,l}y_{i,l} below it, to which those of
+// the samples missing the feature are added when they go below it.
+func stumpVotes(total, below, missingBelow []float64) ([]int, float64) {
+    edge := 0.0
+    votes := make([]int, len(total))
+    for l := range total {
+        labelEdge := total[l] - 2 * below[l]
+        if missingBelow != nil {
+            labelEdge -= 2 * missingBelow[l]
+        }
+        votes[l] = 1
+        if labelEdge < 0 {
+            votes[l] = -1
+        }
+        edge += math.Abs(labelEdge)
+    }
+    return votes, edge
+}
@@ -0,0 +1,99 @@
+package classifier
+
+import "fmt"
+
+// Runtime options of the classifiers, their weak learners and the evaluators.
+type Options struct {
+
+    // Boosting mode of the binary classifier.
+    Mode BoostingMode `json:"mode"`
+
+    // Number of boosting rounds, T.
+    NumberOfClassifiers uint `json:"number_of_classifiers"`
+
+    // Shrinkage ν of the boosting, scaling the alpha of each weak classifier to ν·α_{t}, or the weight of each regressor
+    // of AdaBoost.R2. Values below 1 take smaller steps, needing more rounds but usually generalizing better.
+    LearningRate float64 `json:"learning_rate"`
+
+    // Generates random stumps each round instead of searching all splits.
+    UseRandomWeakClassifiers bool `json:"use_random_weak_classifiers"`
+
+    // Number of random stumps generated each round, when using random weak classifiers.
+    NumberOfRandomClassifiers int `json:"number_of_random_classifiers"`
+
+    // Initializes the weights inversely proportional to the class rates.
+    IncorporateCostSensitiveLearning bool `json:"incorporate_cost_sensitive_learning"`
+
+    // Evaluates discrete classifiers comparing the positive votes to half of the sum of the alphas.
+    UseThresholdClassification bool `json:"use_threshold_classification"`
+
+    // Over samples the minority class of the training set before training.
+    OverSamplingTrainingSet bool `json:"over_sampling_training_set"`
+
+    // Fraction of the samples held out for testing.
+    TestPercent float64 `json:"test_percent"`
+
+    // Fraction of the samples held out from training to stop early on.
+    ValidationPercent float64 `json:"validation_percent"`
+
+    // Stops training after this many rounds without improving the metric over the validation set, keeping the
+    // weak classifiers up to the best round. Zero never stops early.
+    EarlyStoppingRounds uint `json:"early_stopping_rounds"`
+
+    // Metric of the validation set deciding when to stop: error, log-loss or exponential-loss. Defaults to error.
+    EarlyStoppingMetric StoppingMetric `json:"early_stopping_metric"`
+
+    // Number of goroutines searching the weak classifiers. Zero uses one per CPU.
+    NumberOfWorkers int `json:"number_of_workers"`
+
+    // Features holding categories, which the weak learners of the binary classifier split into subsets instead of
+    // thresholds.
+    CategoricalFeatures []uint `json:"categorical_features"`
+}
+
+func DefaultOptions() Options {
+    return Options{
+        Mode: DISCRETE_ADABOOST,
+        NumberOfClassifiers: 100,
+        LearningRate: 1,
+        UseRandomWeakClassifiers: false,
+        NumberOfRandomClassifiers: 10,
+        IncorporateCostSensitiveLearning: true,
+        UseThresholdClassification: true,
+        OverSamplingTrainingSet: false,
+        TestPercent: 0.4,
+        ValidationPercent: 0,
+        EarlyStoppingRounds: 0,
+        EarlyStoppingMetric: ERROR_METRIC,
+        NumberOfWorkers: 0,
+    }
+}
+
+// Checks the options are consistent.
+func (o Options) Validate() error {
+    if _, ok := boostingModeNames[o.Mode]; !ok {
+        return fmt.Errorf("unknown boosting mode %s", o.Mode)
+    }
+    if o.NumberOfClassifiers < 1 {
+        return fmt.Errorf("number of classifiers must be at least 1")
+    }
+    if o.LearningRate <= 0 || o.LearningRate > 1 {
+        return fmt.Errorf("learning rate must be in (0, 1], got %f", o.LearningRate)
+    }
+    if o.UseRandomWeakClassifiers && o.NumberOfRandomClassifiers < 1 {
+        return fmt.Errorf("number of random classifiers must be at least 1, got %d", o.NumberOfRandomClassifiers)
+    }
+    if o.TestPercent < 0 || o.TestPercent >= 1 {
+        return fmt.Errorf("test percent must be in [0, 1), got %f", o.TestPercent)
+    }
+    if o.ValidationPercent < 0 || o.TestPercent + o.ValidationPercent >= 1 {
+        return fmt.Errorf("validation percent must be in [0, 1 - test percent), got %f", o.ValidationPercent)
+    }
+    if _, ok := stoppingMetricNames[o.EarlyStoppingMetric]; !ok {
+        return fmt.Errorf("unknown stopping metric %s", o.EarlyStoppingMetric)
+    }
+    if o.NumberOfWorkers < 0 {
+        return fmt.Errorf("number of workers must not be negative, got %d", o.NumberOfWorkers)
+    }
+    return nil
+}
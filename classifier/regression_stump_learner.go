@@ -0,0 +1,298 @@
+package classifier
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+)
+
+// Weak learner fitting regression stumps by weighted least squares, as used by Gentle AdaBoost and LogitBoost.
+//
+// Each side of the split outputs the weighted mean of the targets of its samples, and the split minimizing the
+// weighted squared error is chosen:
+// \epsilon = \sum_{i=1}^{m}w_{i}(z_{i} - f(x_{i}))^{2}
+//
+// When generating weak classifiers the targets are the labels, so the leaf values lie in [-1, 1].
+// The error of the generated stumps holds the weighted squared error. The samples missing the feature join the side
+// giving the smaller error.
+type RegressionStumpLearner struct {
+    featureSearcher
+}
+
+func NewRegressionStumpLearner(options Options) *RegressionStumpLearner {
+    return &RegressionStumpLearner{featureSearcher: newFeatureSearcher(options.NumberOfWorkers, options.CategoricalFeatures)}
+}
+
+// Builds the sorted index for the given training set.
+func (w *RegressionStumpLearner) Prepare(samples [][]float64) {
+    w.buildIndex(samples)
+}
+
+// Builds the sorted columns for the given sparse training set.
+func (w *RegressionStumpLearner) PrepareSparse(samples *utils.SparseSamples) {
+    w.buildSparseIndex(samples)
+}
+
+// Learn regression weak classifier f_{t} fitting the labels using distribution D_{t}.
+func (w *RegressionStumpLearner) GenerateWeakClassifier(samples [][]float64, weights []float64) (WeakClassifier, error) {
+    if err := utils.ValidateSamples(samples, 1); err != nil {
+        return nil, err
+    }
+    targets := make([]float64, len(samples))
+    for i, sample := range samples {
+        targets[i] = sample[len(sample) - 1]
+    }
+    return w.fit(samples, targets, weights)
+}
+
+// Learn regression f_{t} fitting the targets by weighted least squares.
+func (w *RegressionStumpLearner) GenerateRegressor(samples [][]float64, targets []float64, weights []float64) (WeakClassifier, error) {
+    if err := utils.ValidateSamples(samples, 1); err != nil {
+        return nil, err
+    }
+    return w.fit(samples, targets, weights)
+}
+
+// Fits a regression stump to the targets using the weights.
+// Ties are broken by the lowest feature number and then by the lowest split.
+// The samples must have been validated.
+func (w *RegressionStumpLearner) fit(samples [][]float64, targets []float64, weights []float64) (WeakClassifier, error) {
+
+    numberOfFeatures := uint(len(samples[0]) - 1)
+    if !w.hasIndexFor(samples) {
+        w.Prepare(samples)
+    }
+
+    var total weightedMoments
+    for i := range samples {
+        total.add(targets[i], weights[i])
+    }
+    best := w.search(numberOfFeatures, func(featureNumber uint) searchCandidate {
+        if stump := w.findBestSplit(samples, targets, weights, nil, featureNumber, total); stump != nil {
+            return stump
+        }
+        return nil
+    })
+
+    // Every feature is missing from every sample.
+    if best == nil {
+        return nil, utils.ErrDegenerateWeakClassifier
+    }
+    return best.(*ConfidenceStump), nil
+}
+
+// Finds the split of a feature minimizing the weighted squared error with a single sweep over the sorted samples.
+// When members is not nil, only the samples it marks are considered.
+func (w *RegressionStumpLearner) findBestSplit(samples [][]float64, targets []float64, weights []float64, members []bool, featureNumber uint, total weightedMoments) *ConfidenceStump {
+    if column := w.categories[featureNumber]; column != nil {
+        return w.findBestCategoricalSplit(column, targets, weights, members, featureNumber, total)
+    }
+    var best *ConfidenceStump
+    index, missingIndex := w.presentAndMissing(samples, featureNumber)
+    var missing weightedMoments
+    for _, sampleIndex := range missingIndex {
+        if members == nil || members[sampleIndex] {
+            missing.add(targets[sampleIndex], weights[sampleIndex])
+        }
+    }
+    var below weightedMoments
+    for i, sampleIndex := range index {
+        if members != nil && !members[sampleIndex] {
+            continue
+        }
+        below.add(targets[sampleIndex], weights[sampleIndex])
+        split := samples[sampleIndex][featureNumber]
+
+        // Only evaluates the split after the last sample holding the same value.
+        if next := w.nextMember(index, i, members); next < len(index) && samples[index[next]][featureNumber] == split {
+            continue
+        }
+        if stump := newRegressionSplitStump(featureNumber, split, below, missing, total); best == nil || stump.GetError() < best.GetError() {
+            best = stump
+        }
+    }
+    return best
+}
+
+// Learn regression weak classifier f_{t} over sparse samples fitting the labels using distribution D_{t}.
+func (w *RegressionStumpLearner) GenerateSparseWeakClassifier(samples *utils.SparseSamples, weights []float64) (WeakClassifier, error) {
+    if err := samples.Validate(); err != nil {
+        return nil, err
+    }
+    return w.fitSparse(samples, samples.Labels, weights)
+}
+
+// Learn regression f_{t} over sparse samples fitting the targets by weighted least squares.
+func (w *RegressionStumpLearner) GenerateSparseRegressor(samples *utils.SparseSamples, targets []float64, weights []float64) (WeakClassifier, error) {
+    if err := samples.Validate(); err != nil {
+        return nil, err
+    }
+    return w.fitSparse(samples, targets, weights)
+}
+
+// Fits a regression stump to the targets of sparse samples using the weights, as fit does.
+func (w *RegressionStumpLearner) fitSparse(samples *utils.SparseSamples, targets []float64, weights []float64) (WeakClassifier, error) {
+    if !w.hasSparseIndexFor(samples) {
+        w.PrepareSparse(samples)
+    }
+
+    var total weightedMoments
+    for i := range targets {
+        total.add(targets[i], weights[i])
+    }
+    best := w.search(uint(samples.NumberOfFeatures), func(featureNumber uint) searchCandidate {
+        if stump := w.findBestSparseSplit(targets, weights, featureNumber, total); stump != nil {
+            return stump
+        }
+        return nil
+    })
+
+    // Every feature is missing from every sample.
+    if best == nil {
+        return nil, utils.ErrDegenerateWeakClassifier
+    }
+    return best.(*ConfidenceStump), nil
+}
+
+// Finds the split of a feature of sparse samples minimizing the weighted squared error with a single sweep over its
+// sorted column. The moments of the samples whose feature is 0 are the total ones minus those of the stored samples.
+func (w *RegressionStumpLearner) findBestSparseSplit(targets []float64, weights []float64, featureNumber uint, total weightedMoments) *ConfidenceStump {
+    if column := w.categories[featureNumber]; column != nil {
+        return w.findBestCategoricalSplit(column, targets, weights, nil, featureNumber, total)
+    }
+    var best *ConfidenceStump
+    var stored, missing weightedMoments
+    rows, _ := w.sparseIndex.Column(int(featureNumber))
+    for _, row := range rows {
+        stored.add(targets[row], weights[row])
+    }
+    _, _, missingRows := w.sparsePresentAndMissing(featureNumber)
+    for _, row := range missingRows {
+        missing.add(targets[row], weights[row])
+    }
+    zeros := total.minus(stored)
+    var below weightedMoments
+    w.sweepSparse(featureNumber, func(row int, split float64, last bool) {
+        if row < 0 {
+            below = below.plus(zeros)
+        } else {
+            below.add(targets[row], weights[row])
+        }
+        if !last {
+            return
+        }
+        if stump := newRegressionSplitStump(featureNumber, split, below, missing, total); best == nil || stump.GetError() < best.GetError() {
+            best = stump
+        }
+    })
+    return best
+}
+
+// Finds the subset of the categories of a categorical feature minimizing the weighted squared error, the samples
+// holding them getting the right value. Only the splits of the categories ordered by the weighted mean of their
+// targets are evaluated. When members is not nil, only the samples it marks are considered.
+func (w *RegressionStumpLearner) findBestCategoricalSplit(column *categoricalColumn, targets []float64, weights []float64, members []bool, featureNumber uint, total weightedMoments) *ConfidenceStump {
+    var best *ConfidenceStump
+    bestPosition := 0
+    moments := make([]weightedMoments, len(column.values))
+    var missing weightedMoments
+    for row, code := range column.codes {
+        if members != nil && !members[row] {
+            continue
+        }
+        if code < 0 {
+            missing.add(targets[row], weights[row])
+        } else {
+            moments[code].add(targets[row], weights[row])
+        }
+    }
+    means := make([]float64, len(moments))
+    for c := range moments {
+        means[c] = moments[c].mean()
+    }
+    order := orderCategories(means)
+    var below weightedMoments
+    for i := 0; i + 1 < len(order); i++ {
+        below = below.plus(moments[order[i]])
+        if stump := newRegressionSplitStump(featureNumber, 0, below, missing, total); best == nil || stump.GetError() < best.GetError() {
+            best = stump
+            bestPosition = i + 1
+        }
+    }
+    if best != nil {
+        best.SetCategories(column.categoriesAt(order[bestPosition:]))
+    }
+    return best
+}
+
+// Creates the regression stump splitting a feature at split, given the moments of the samples with value <= split,
+// of the samples missing the feature and of all of them. The missing samples join the side giving the smaller
+// squared error, the left one on ties.
+func newRegressionSplitStump(featureNumber uint, split float64, below, missing, total weightedMoments) *ConfidenceStump {
+    above := total.minus(below).minus(missing)
+    belowWithMissing := below.plus(missing)
+    aboveWithMissing := above.plus(missing)
+    errorMissingBelow := belowWithMissing.squaredError() + above.squaredError()
+    errorMissingAbove := below.squaredError() + aboveWithMissing.squaredError()
+    if errorMissingAbove < errorMissingBelow {
+        stump := NewConfidenceStump(featureNumber, split, below.mean(), aboveWithMissing.mean())
+        stump.SetMissingAbove(true)
+        stump.SetError(errorMissingAbove)
+        return stump
+    }
+    stump := NewConfidenceStump(featureNumber, split, belowWithMissing.mean(), above.mean())
+    stump.SetError(errorMissingBelow)
+    return stump
+}
+
+// Gets the position in the index of the next member after position i, or the length of the index when none.
+func (w *RegressionStumpLearner) nextMember(index []int, i int, members []bool) int {
+    i++
+    for members != nil && i < len(index) && !members[index[i]] {
+        i++
+    }
+    return i
+}
+
+// Weighted sums needed to compute the mean and squared error of a set of targets.
+type weightedMoments struct {
+    weight         float64
+    weightedSum    float64
+    weightedSquare float64
+}
+
+func (m *weightedMoments) add(target, weight float64) {
+    m.weight += weight
+    m.weightedSum += weight * target
+    m.weightedSquare += weight * target * target
+}
+
+func (m weightedMoments) plus(other weightedMoments) weightedMoments {
+    return weightedMoments{
+        weight: m.weight + other.weight,
+        weightedSum: m.weightedSum + other.weightedSum,
+        weightedSquare: m.weightedSquare + other.weightedSquare,
+    }
+}
+
+func (m weightedMoments) minus(other weightedMoments) weightedMoments {
+    return weightedMoments{
+        weight: m.weight - other.weight,
+        weightedSum: m.weightedSum - other.weightedSum,
+        weightedSquare: m.weightedSquare - other.weightedSquare,
+    }
+}
+
+// Weighted mean of the targets, zero for an empty set.
+func (m weightedMoments) mean() float64 {
+    if m.weight <= 0 {
+        return 0
+    }
+    return m.weightedSum / m.weight
+}
+
+// \sum_{i}w_{i}(z_{i} - \bar{z})^{2} = \sum_{i}w_{i}z_{i}^{2} - \frac{(\sum_{i}w_{i}z_{i})^{2}}{\sum_{i}w_{i}}
+func (m weightedMoments) squaredError() float64 {
+    if m.weight <= 0 {
+        return 0
+    }
+    return m.weightedSquare - m.weightedSum * m.weightedSum / m.weight
+}
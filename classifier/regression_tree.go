@@ -0,0 +1,148 @@
+package classifier
+
+import (
+    "fmt"
+    "sort"
+)
+
+const REGRESSION_TREE_KIND = "regression_tree"
+
+// Node of a regression tree. Leaves have no children and output their value.
+type RegressionNode struct {
+    FeatureNumber uint
+    Split         float64
+
+    // Sorted categories sending the samples holding them to the right child, nil when the split is a threshold.
+    Categories []float64
+
+    // Whether the samples missing the feature go to the right child.
+    MissingAbove bool
+
+    Value float64
+    Left  *RegressionNode
+    Right *RegressionNode
+}
+
+func (n *RegressionNode) IsLeaf() bool {
+    return n.Left == nil || n.Right == nil
+}
+
+// Tells if a sample goes to the right child.
+func (n *RegressionNode) goesRight(sample []float64) bool {
+    return goesAbove(sample[n.FeatureNumber], n.Split, n.Categories, n.MissingAbove)
+}
+
+// Binary regression tree, splitting samples by single feature thresholds until the leaves.
+// Samples less than or equal to the split of a node go to the left child and the others to the right one.
+type RegressionTree struct {
+    root  *RegressionNode
+    error float64
+    alpha float64
+}
+
+func NewRegressionTree(root *RegressionNode) *RegressionTree {
+    return &RegressionTree{root: root, alpha: 1}
+}
+
+// The output is already part of the leaf values, so α_{t} = 1.
+func (c *RegressionTree) ComputeAlpha() {
+    c.alpha = 1
+}
+
+// Gets the value of the leaf the sample falls into.
+func (c *RegressionTree) Value(sample []float64) float64 {
+    node := c.root
+    for !node.IsLeaf() {
+        if node.goesRight(sample) {
+            node = node.Right
+        } else {
+            node = node.Left
+        }
+    }
+    return node.Value
+}
+
+func (c *RegressionTree) Classify(sample []float64) int {
+    if c.Value(sample) > 0 {
+        return 1
+    }
+    return -1
+}
+
+func (c *RegressionTree) ClassifyWithAlpha(sample []float64) float64 {
+    return c.Value(sample) * c.alpha
+}
+
+func (c *RegressionTree) GetRoot() *RegressionNode {
+    return c.root
+}
+
+func (c *RegressionTree) GetFeatureNumbers() []uint {
+    seen := make(map[uint]bool)
+    var featureNumbers []uint
+    var visit func(node *RegressionNode)
+    visit = func(node *RegressionNode) {
+        if node.IsLeaf() {
+            return
+        }
+        if !seen[node.FeatureNumber] {
+            seen[node.FeatureNumber] = true
+            featureNumbers = append(featureNumbers, node.FeatureNumber)
+        }
+        visit(node.Left)
+        visit(node.Right)
+    }
+    visit(c.root)
+    sort.Slice(featureNumbers, func(a, b int) bool {
+        return featureNumbers[a] < featureNumbers[b]
+    })
+    return featureNumbers
+}
+
+func (c *RegressionTree) SetError(error float64) {
+    c.error = error
+}
+
+func (c *RegressionTree) GetError() float64 {
+    return c.error
+}
+
+func (c *RegressionTree) SetAlpha(alpha float64) {
+    c.alpha = alpha
+}
+
+func (c *RegressionTree) GetAlpha() float64 {
+    return c.alpha
+}
+
+func (c *RegressionTree) Kind() string {
+    return REGRESSION_TREE_KIND
+}
+
+func (c *RegressionTree) ToMap() map[string]interface{} {
+    var tree = make(map[string]interface{})
+    tree["root"] = regressionNodeToMap(c.root)
+    tree["weight"] = c.alpha
+    return tree
+}
+
+func regressionNodeToMap(node *RegressionNode) map[string]interface{} {
+    var m = make(map[string]interface{})
+    if node.IsLeaf() {
+        m["value"] = node.Value
+        return m
+    }
+    m["feature_number"] = node.FeatureNumber
+    m["split"] = node.Split
+    m["missing_above"] = node.MissingAbove
+    if node.Categories != nil {
+        m["category"] = node.Categories
+    }
+    m["left"] = regressionNodeToMap(node.Left)
+    m["right"] = regressionNodeToMap(node.Right)
+    return m
+}
+
+func (c *RegressionTree) String() string {
+    return fmt.Sprintf("featureNumbers: %v, error: %f, apha: %f", c.GetFeatureNumbers(), c.error, c.alpha)
+}
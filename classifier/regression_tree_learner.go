@@ -0,0 +1,105 @@
+package classifier
+
+import "github.com/dalmirdasilva/AdaBoostGo/utils"
+
+// Depth of the trees generated by default, as used by AdaBoost.R2.
+const DEFAULT_REGRESSION_TREE_DEPTH = 3
+
+// Weak learner growing regression trees by weighted least squares.
+//
+// Each node is split like a regression stump over the samples reaching it, until the maximum depth is reached or
+// no split reduces the weighted squared error. Leaves output the weighted mean of the targets of their samples.
+// The sorted index of the training set is shared by all nodes.
+type RegressionTreeLearner struct {
+    RegressionStumpLearner
+    maxDepth uint
+}
+
+func NewRegressionTreeLearner(maxDepth uint, options Options) *RegressionTreeLearner {
+    return &RegressionTreeLearner{RegressionStumpLearner: *NewRegressionStumpLearner(options), maxDepth: maxDepth}
+}
+
+// Learn regression tree f_{t} fitting the labels using distribution D_{t}.
+func (w *RegressionTreeLearner) GenerateWeakClassifier(samples [][]float64, weights []float64) (WeakClassifier, error) {
+    if err := utils.ValidateSamples(samples, 1); err != nil {
+        return nil, err
+    }
+    targets := make([]float64, len(samples))
+    for i, sample := range samples {
+        targets[i] = sample[len(sample) - 1]
+    }
+    return w.GenerateRegressor(samples, targets, weights)
+}
+
+// Learn regression tree f_{t} fitting the targets by weighted least squares.
+func (w *RegressionTreeLearner) GenerateRegressor(samples [][]float64, targets []float64, weights []float64) (WeakClassifier, error) {
+
+    if err := utils.ValidateSamples(samples, 1); err != nil {
+        return nil, err
+    }
+    numberOfSamples := len(samples)
+
+    if !w.hasIndexFor(samples) {
+        w.Prepare(samples)
+    }
+
+    members := make([]bool, numberOfSamples)
+    for i := range members {
+        members[i] = true
+    }
+    tree := NewRegressionTree(w.grow(samples, targets, weights, members, 0))
+    squaredError := 0.0
+    for i, sample := range samples {
+        difference := targets[i] - tree.Value(sample)
+        squaredError += weights[i] * difference * difference
+    }
+    tree.SetError(squaredError)
+    return tree, nil
+}
+
+// Grows the node holding the member samples.
+func (w *RegressionTreeLearner) grow(samples [][]float64, targets []float64, weights []float64, members []bool, depth uint) *RegressionNode {
+    var total weightedMoments
+    for i, member := range members {
+        if member {
+            total.add(targets[i], weights[i])
+        }
+    }
+    node := &RegressionNode{Value: total.mean()}
+    if depth >= w.maxDepth {
+        return node
+    }
+
+    numberOfFeatures := uint(len(samples[0]) - 1)
+    best := w.search(numberOfFeatures, func(featureNumber uint) searchCandidate {
+        if stump := w.findBestSplit(samples, targets, weights, members, featureNumber, total); stump != nil {
+            return stump
+        }
+        return nil
+    })
+
+    // Stops when no split reduces the error.
+    if best == nil || best.GetError() >= total.squaredError() {
+        return node
+    }
+    stump := best.(*ConfidenceStump)
+    node.FeatureNumber = stump.GetFeatureNumber()
+    node.Split = stump.GetSplit()
+    node.Categories = stump.GetCategories()
+    node.MissingAbove = stump.GetMissingAbove()
+
+    left := make([]bool, len(members))
+    right := make([]bool, len(members))
+    for i, member := range members {
+        if member {
+            if node.goesRight(samples[i]) {
+                right[i] = true
+            } else {
+                left[i] = true
+            }
+        }
+    }
+    node.Left = w.grow(samples, targets, weights, left, depth + 1)
+    node.Right = w.grow(samples, targets, weights, right, depth + 1)
+    return node
+}
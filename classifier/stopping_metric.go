@@ -0,0 +1,79 @@
+package classifier
+
+import (
+    "fmt"
+    "math"
+)
+
+// Metric of the validation set tracked after each round when stopping early. Lower values are better.
+type StoppingMetric int
+
+const (
+
+    // Fraction of the validation samples misclassified by sign(F(x)).
+    ERROR_METRIC StoppingMetric = iota
+
+    // Mean negative log-likelihood of the labels under the probability estimated by PredictProba.
+    LOG_LOSS_METRIC
+
+    // Mean exponential loss e^{-yF(x)}, the loss AdaBoost minimizes.
+    EXPONENTIAL_LOSS_METRIC
+)
+
+var stoppingMetricNames = map[StoppingMetric]string{
+    ERROR_METRIC: "error",
+    LOG_LOSS_METRIC: "log-loss",
+    EXPONENTIAL_LOSS_METRIC: "exponential-loss",
+}
+
+func (m StoppingMetric) String() string {
+    if name, ok := stoppingMetricNames[m]; ok {
+        return name
+    }
+    return fmt.Sprintf("StoppingMetric(%d)", int(m))
+}
+
+// Parses the name of a stopping metric. An empty name is the error metric.
+func ParseStoppingMetric(name string) (StoppingMetric, bool) {
+    if name == "" {
+        return ERROR_METRIC, true
+    }
+    for metric, metricName := range stoppingMetricNames {
+        if metricName == name {
+            return metric, true
+        }
+    }
+    return ERROR_METRIC, false
+}
+
+func (m StoppingMetric) MarshalText() ([]byte, error) {
+    return []byte(m.String()), nil
+}
+
+func (m *StoppingMetric) UnmarshalText(text []byte) error {
+    metric, ok := ParseStoppingMetric(string(text))
+    if !ok {
+        return fmt.Errorf("unknown stopping metric %s", text)
+    }
+    *m = metric
+    return nil
+}
+
+// Computes the loss of a sample of label y given its score F(x). The probability of the log loss is clipped as the
+// evaluator does, so the loss stays finite.
+func (m StoppingMetric) loss(y float64, score float64) float64 {
+    switch m {
+    case LOG_LOSS_METRIC:
+        p := math.Max(math.Min(1 / (1 + math.Exp(-2 * score)), 1 - 1e-15), 1e-15)
+        if y > 0 {
+            return -math.Log(p)
+        }
+        return -math.Log(1 - p)
+    case EXPONENTIAL_LOSS_METRIC:
+        return math.Exp(-y * score)
+    }
+    if (score > 0) != (y > 0) {
+        return 1
+    }
+    return 0
+}
@@ -0,0 +1,158 @@
+package classifier
+
+import (
+    "fmt"
+    "math"
+)
+
+const STUMP_KIND = "stump"
+
+// Single threshold weak classifier.
+//
+// The polarity tells the direction of the split: samples above the split are classified as the polarity, 1 or -1,
+// and samples below it as the opposite class. Samples missing the feature, holding NaN, go below the split unless
+// missingAbove is set.
+//
+// Stumps of categorical features have categories instead of a split: samples holding one of them are above the split
+// and the others below it.
+type Stump struct {
+    featureNumber uint
+    split         float64
+    categories    []float64
+    polarity      int
+    missingAbove  bool
+    error         float64
+    alpha         float64
+}
+
+func NewStump(featureNumber uint, split float64) *Stump {
+    return NewStumpWithPolarity(featureNumber, split, 1)
+}
+
+func NewStumpWithPolarity(featureNumber uint, split float64, polarity int) *Stump {
+    return &Stump{featureNumber: featureNumber, split: split, polarity: polarity}
+}
+
+// Set weight α_{t} based on the error
+// Computes the following equation:
+// \alpha_{t} = \frac{1}{2}\ln\left( \frac{1 - \epsilon_{t}(h_{t})}{\epsilon_{t}(h_{t})}\right)
+//
+// The error is clamped to [MIN_CLASS_PROBABILITY, 1 - MIN_CLASS_PROBABILITY], so a stump separating the training set
+// gets a large but finite alpha and the weights stay a distribution.
+func (c *Stump) ComputeAlpha() {
+    error := math.Min(math.Max(c.error, MIN_CLASS_PROBABILITY), 1 - MIN_CLASS_PROBABILITY)
+    c.alpha = 0.5 * math.Log((1.0 - error) / error)
+}
+
+func (c *Stump) Classify(sample []float64) int {
+    return c.classifyValue(sample[c.featureNumber])
+}
+
+// Classifies a value of the stump's feature.
+func (c *Stump) classifyValue(value float64) int {
+    if goesAbove(value, c.split, c.categories, c.missingAbove) {
+        return c.polarity
+    }
+    return -c.polarity
+}
+
+func (c *Stump) ClassifyWithAlpha(sample []float64) float64 {
+    return float64(c.Classify(sample)) * c.alpha
+}
+
+func (c *Stump) IncreaseError(amount float64) {
+    c.error += amount
+}
+
+func (c *Stump) SetFeatureNumber(featureNumber uint) {
+    c.featureNumber = featureNumber
+}
+
+func (c *Stump) GetFeatureNumber() uint {
+    return c.featureNumber
+}
+
+func (c *Stump) GetFeatureNumbers() []uint {
+    return []uint{c.featureNumber}
+}
+
+func (c *Stump) SetSplit(split float64) {
+    c.split = split
+}
+
+func (c *Stump) GetSplit() float64 {
+    return c.split
+}
+
+func (c *Stump) SetPolarity(polarity int) {
+    c.polarity = polarity
+}
+
+func (c *Stump) GetPolarity() int {
+    return c.polarity
+}
+
+// Makes the stump categorical, sending above the split the samples holding one of the categories, which must be sorted.
+func (c *Stump) SetCategories(categories []float64) {
+    c.categories = categories
+}
+
+// Gets the categories going above the split, nil when the stump is not categorical.
+func (c *Stump) GetCategories() []float64 {
+    return c.categories
+}
+
+// Tells to which side of the split the samples missing the feature go.
+func (c *Stump) SetMissingAbove(missingAbove bool) {
+    c.missingAbove = missingAbove
+}
+
+func (c *Stump) GetMissingAbove() bool {
+    return c.missingAbove
+}
+
+// Inverts the direction of the split, turning an error ε into 1 - ε for normalized weights.
+func (c *Stump) FlipPolarity(totalWeight float64) {
+    c.polarity = -c.polarity
+    c.error = totalWeight - c.error
+}
+
+func (c *Stump) SetError(error float64) {
+    c.error = error
+}
+
+func (c *Stump) GetError() float64 {
+    return c.error
+}
+
+func (c *Stump) SetAlpha(alpha float64) {
+    c.alpha = alpha
+}
+
+func (c *Stump) GetAlpha() float64 {
+    return c.alpha
+}
+
+func (c *Stump) Kind() string {
+    return STUMP_KIND
+}
+
+func (c *Stump) ToMap() map[string]interface{} {
+    var stump = make(map[string]interface{})
+    stump["feature_number"] = c.featureNumber
+    stump["split"] = c.split
+    stump["polarity"] = c.polarity
+    stump["missing_above"] = c.missingAbove
+    if c.categories != nil {
+        stump["category"] = c.categories
+    }
+    stump["weight"] = c.alpha
+    return stump
+}
+
+func (c *Stump) String() string {
+    if c.categories != nil {
+        return fmt.Sprintf("featureNumber: %d, categories: %v, polarity: %d, missingAbove: %t, error: %f, apha: %f", c.featureNumber, c.categories, c.polarity, c.missingAbove, c.error, c.alpha)
+    }
+    return fmt.Sprintf("featureNumber: %d, split: %f, polarity: %d, missingAbove: %t, error: %f, apha: %f", c.featureNumber, c.split, c.polarity, c.missingAbove, c.error, c.alpha)
+}
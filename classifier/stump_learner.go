@@ -0,0 +1,435 @@
+package classifier
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/statistics"
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+    "math/rand"
+    "math"
+    "fmt"
+)
+
+// Weak learner generating single threshold stumps.
+//
+// When not using random classifiers it searches all unique values of every feature using a sorted index, finding
+// the optimal split of a feature with a single sweep accumulating the weights below each candidate split. The
+// categorical features are split into subsets of their categories instead.
+type StumpLearner struct {
+    featureSearcher
+    analyzer                  statistics.FeaturesAnalyzer
+    usedSplits                []map[float64]bool
+    usedCategories            []map[string]bool
+    useRandomWeakClassifiers  bool
+    numberOfRandomClassifiers int
+}
+
+func NewStumpLearner(options Options) *StumpLearner {
+    return &StumpLearner{
+        featureSearcher: newFeatureSearcher(options.NumberOfWorkers, options.CategoricalFeatures),
+        analyzer: statistics.NewFeaturesAnalyzer(),
+        useRandomWeakClassifiers: options.UseRandomWeakClassifiers,
+        numberOfRandomClassifiers: options.NumberOfRandomClassifiers,
+    }
+}
+
+// Builds the sorted index for the given training set and forgets the used splits.
+func (w *StumpLearner) Prepare(samples [][]float64) {
+    w.buildIndex(samples)
+    w.resetUsedSplits(len(w.sortedIndex))
+}
+
+// Builds the sorted columns for the given sparse training set and forgets the used splits.
+func (w *StumpLearner) PrepareSparse(samples *utils.SparseSamples) {
+    w.buildSparseIndex(samples)
+    w.resetUsedSplits(samples.NumberOfFeatures)
+}
+
+func (w *StumpLearner) resetUsedSplits(numberOfFeatures int) {
+    w.usedSplits = make([]map[float64]bool, numberOfFeatures)
+    w.usedCategories = make([]map[string]bool, numberOfFeatures)
+    for i := range w.usedSplits {
+        w.usedSplits[i] = make(map[float64]bool)
+        w.usedCategories[i] = make(map[string]bool)
+    }
+}
+
+// Uses FeaturesAnalyzer to analyze the samples.
+// It computes min, max, avg, std...
+func (w *StumpLearner) analyzeFeatures(samples [][]float64) ([]statistics.FeatureStatistic, error) {
+    statistics, _, err := w.analyzer.Analyze(samples)
+    return statistics, err
+}
+
+// Learn weak classifier h_{t} using distribution D_{t}.
+//
+// Compute the weighted error for each weak classifier.
+// Select the weak classifier with minimum error.
+//
+// Implementation of the following equation:
+// h_{t} = \underset {h_{j}\in H}{\operatorname {arg\,min} }\,\epsilon_{j} = \sum_{i=1}^{m}D_{t}\left [y_{i} \neq h_{j}(x_{i}) \right ]
+//
+// Ties are broken by the lowest feature number and then by the lowest split.
+func (w *StumpLearner) GenerateWeakClassifier(samples [][]float64, weights []float64) (WeakClassifier, error) {
+
+    if err := utils.ValidateSamples(samples, 1); err != nil {
+        return nil, err
+    }
+    numberOfFeatures := uint(len(samples[0]) - 1)
+
+    // The index is built lazily when the learner is used without being prepared.
+    if !w.hasIndexFor(samples) {
+        w.Prepare(samples)
+    }
+
+    if w.useRandomWeakClassifiers {
+        return w.selectBestRandomClassifier(samples, weights, numberOfFeatures)
+    }
+
+    positiveWeight, negativeWeight := sumClassWeights(samples, weights)
+    best := w.search(numberOfFeatures, func(featureNumber uint) searchCandidate {
+        if stump := w.findBestSplit(samples, weights, featureNumber, positiveWeight, negativeWeight); stump != nil {
+            return stump
+        }
+        return nil
+    })
+    return w.retain(best)
+}
+
+// Learn weak classifier h_{t} over sparse samples using distribution D_{t}, as GenerateWeakClassifier does.
+// The split search takes time proportional to the features other than 0.
+func (w *StumpLearner) GenerateSparseWeakClassifier(samples *utils.SparseSamples, weights []float64) (WeakClassifier, error) {
+
+    if err := samples.Validate(); err != nil {
+        return nil, err
+    }
+    numberOfFeatures := uint(samples.NumberOfFeatures)
+
+    // The index is built lazily when the learner is used without being prepared.
+    if !w.hasSparseIndexFor(samples) {
+        w.PrepareSparse(samples)
+    }
+
+    if w.useRandomWeakClassifiers {
+        return w.selectBestRandomSparseClassifier(samples, weights, numberOfFeatures)
+    }
+
+    positiveWeight, negativeWeight := sumSparseClassWeights(samples, weights)
+    best := w.search(numberOfFeatures, func(featureNumber uint) searchCandidate {
+        if stump := w.findBestSparseSplit(samples, weights, featureNumber, positiveWeight, negativeWeight); stump != nil {
+            return stump
+        }
+        return nil
+    })
+    return w.retain(best)
+}
+
+// Returns the best stump of a search, marking its split as used.
+func (w *StumpLearner) retain(best searchCandidate) (WeakClassifier, error) {
+
+    // All possible weak classifiers were already used.
+    if best == nil {
+        return nil, utils.ErrDegenerateWeakClassifier
+    }
+    stump := best.(*Stump)
+
+    // Marks the split as used to avoid piking the same classifier more than once.
+    if stump.GetCategories() != nil {
+        w.usedCategories[stump.GetFeatureNumber()][fmt.Sprint(stump.GetCategories())] = true
+    } else {
+        w.usedSplits[stump.GetFeatureNumber()][stump.GetSplit()] = true
+    }
+    return stump, nil
+}
+
+// Finds the split of a feature with minimum error with a single sweep over the sorted samples.
+//
+// For a split s, samples with value <= s are classified as -1 and the rest as 1, so the error is:
+// \epsilon(s) = W^{+}_{\leq s} + (W^{-} - W^{-}_{\leq s})
+//
+// where the samples missing the feature count as above the split, or as below it when that gives a smaller error.
+// Candidate splits are the unique values of the feature in the training set, except the already used ones.
+func (w *StumpLearner) findBestSplit(samples [][]float64, weights []float64, featureNumber uint, positiveWeight, negativeWeight float64) *Stump {
+    label := func(row int) float64 {
+        return samples[row][len(samples[row]) - 1]
+    }
+    if column := w.categories[featureNumber]; column != nil {
+        return w.findBestCategoricalSplit(column, weights, label, featureNumber, positiveWeight, negativeWeight)
+    }
+    var best *Stump
+    totalWeight := positiveWeight + negativeWeight
+    present, missing := w.presentAndMissing(samples, featureNumber)
+    positiveMissing, negativeMissing := sumRowsClassWeights(missing, weights, label)
+    var positiveBelow, negativeBelow float64
+    for i, sampleIndex := range present {
+        sample := samples[sampleIndex]
+        if sample[len(sample) - 1] > 0 {
+            positiveBelow += weights[sampleIndex]
+        } else {
+            negativeBelow += weights[sampleIndex]
+        }
+        split := sample[featureNumber]
+
+        // Only evaluates the split after the last sample holding the same value.
+        if i + 1 < len(present) && samples[present[i + 1]][featureNumber] == split {
+            continue
+        }
+        if w.usedSplits[featureNumber][split] {
+            continue
+        }
+
+        // Retains the classifier with minor error.
+        if stump := newSplitStump(featureNumber, split, positiveBelow, negativeBelow, positiveMissing, negativeMissing, negativeWeight, totalWeight); best == nil || stump.GetError() < best.GetError() {
+            best = stump
+        }
+    }
+    return best
+}
+
+// Finds the split of a feature of sparse samples with minimum error with a single sweep over its sorted column, as
+// findBestSplit does. The weights of the samples whose feature is 0 are the class weights minus the weights of the
+// stored samples.
+func (w *StumpLearner) findBestSparseSplit(samples *utils.SparseSamples, weights []float64, featureNumber uint, positiveWeight, negativeWeight float64) *Stump {
+    label := func(row int) float64 {
+        return samples.Labels[row]
+    }
+    if column := w.categories[featureNumber]; column != nil {
+        return w.findBestCategoricalSplit(column, weights, label, featureNumber, positiveWeight, negativeWeight)
+    }
+    var best *Stump
+    totalWeight := positiveWeight + negativeWeight
+    storedPositive, storedNegative := w.sumStoredClassWeights(samples, weights, featureNumber)
+    _, _, missing := w.sparsePresentAndMissing(featureNumber)
+    positiveMissing, negativeMissing := sumRowsClassWeights(missing, weights, label)
+    var positiveBelow, negativeBelow float64
+    w.sweepSparse(featureNumber, func(row int, split float64, last bool) {
+        if row < 0 {
+            positiveBelow += positiveWeight - storedPositive
+            negativeBelow += negativeWeight - storedNegative
+        } else if samples.Labels[row] > 0 {
+            positiveBelow += weights[row]
+        } else {
+            negativeBelow += weights[row]
+        }
+        if !last || w.usedSplits[featureNumber][split] {
+            return
+        }
+        if stump := newSplitStump(featureNumber, split, positiveBelow, negativeBelow, positiveMissing, negativeMissing, negativeWeight, totalWeight); best == nil || stump.GetError() < best.GetError() {
+            best = stump
+        }
+    })
+    return best
+}
+
+// Finds the subset of the categories of a categorical feature with minimum error, the samples holding them being
+// classified as 1 and the others as -1.
+//
+// The categories are ordered by their weighted positive rate, and each split of that order into the first categories
+// and the rest is evaluated as a split of sorted values would be.
+func (w *StumpLearner) findBestCategoricalSplit(column *categoricalColumn, weights []float64, label func(row int) float64, featureNumber uint, positiveWeight, negativeWeight float64) *Stump {
+    var best *Stump
+    totalWeight := positiveWeight + negativeWeight
+    positive, negative, positiveMissing, negativeMissing := column.sumClassWeights(weights, label)
+    order := orderCategories(positiveRates(positive, negative))
+    var positiveBelow, negativeBelow float64
+    for i := 0; i + 1 < len(order); i++ {
+        positiveBelow += positive[order[i]]
+        negativeBelow += negative[order[i]]
+        stump := newSplitStump(featureNumber, 0, positiveBelow, negativeBelow, positiveMissing, negativeMissing, negativeWeight, totalWeight)
+        if best != nil && stump.GetError() >= best.GetError() {
+            continue
+        }
+        stump.SetCategories(column.categoriesAt(order[i + 1:]))
+        if !w.usedCategories[featureNumber][fmt.Sprint(stump.GetCategories())] {
+            best = stump
+        }
+    }
+    return best
+}
+
+// Creates the stump splitting a feature at split, given the weights of the samples with value <= split and of the
+// samples missing the feature. When the missing samples go below the split the error is:
+// \epsilon(s) = W^{+}_{\leq s} + W^{+}_{?} + (W^{-} - W^{-}_{\leq s} - W^{-}_{?})
+func newSplitStump(featureNumber uint, split, positiveBelow, negativeBelow, positiveMissing, negativeMissing, negativeWeight, totalWeight float64) *Stump {
+    errorMissingBelow := positiveBelow + positiveMissing + (negativeWeight - negativeBelow - negativeMissing)
+    errorMissingAbove := positiveBelow + (negativeWeight - negativeBelow)
+    return newStumpWithLeastError(featureNumber, split, errorMissingBelow, errorMissingAbove, totalWeight)
+}
+
+// Creates the stump with minimum error among both polarities and both sides for the samples missing the feature,
+// given the errors of polarity 1 with the missing samples below and above the split. Ties keep polarity 1 and the
+// missing samples below the split.
+func newStumpWithLeastError(featureNumber uint, split, errorMissingBelow, errorMissingAbove, totalWeight float64) *Stump {
+    stump := NewStump(featureNumber, split)
+    stump.SetError(errorMissingBelow)
+    if errorMissingAbove < errorMissingBelow {
+        stump.SetError(errorMissingAbove)
+        stump.SetMissingAbove(true)
+    }
+
+    // A stump wrong more than half of the time is better in the opposite direction, the more so with the missing
+    // samples on the side it was most wrong about.
+    worstError, worstAbove := errorMissingBelow, false
+    if errorMissingAbove > worstError {
+        worstError, worstAbove = errorMissingAbove, true
+    }
+    if totalWeight - worstError < stump.GetError() {
+        stump.SetError(worstError)
+        stump.SetMissingAbove(worstAbove)
+        stump.FlipPolarity(totalWeight)
+    }
+    return stump
+}
+
+// Generates a bunch of random weak classifiers and selects the one with minimum error.
+func (w *StumpLearner) selectBestRandomClassifier(samples [][]float64, weights []float64, numberOfFeatures uint) (WeakClassifier, error) {
+    featuresMetrics, err := w.analyzeFeatures(samples)
+    if err != nil {
+        return nil, err
+    }
+    classifiers := w.generateRandomClassifiers(featuresMetrics, numberOfFeatures)
+    if len(*classifiers) == 0 {
+        return nil, utils.ErrDegenerateWeakClassifier
+    }
+
+    totalWeight := 0.0
+    for _, weight := range weights {
+        totalWeight += weight
+    }
+
+    var bestIndex int
+    bestError := math.MaxFloat64
+
+    // Error minimization step.
+    // For each random classifier:
+    for i, _ := range *classifiers {
+        classifier := &(*classifiers)[i]
+        featureNumber := classifier.GetFeatureNumber()
+        errorMissingBelow, errorMissingAbove := 0.0, 0.0
+        for j, sample := range samples {
+            y := sample[len(sample) - 1]
+
+            // The samples missing the feature are wrong below the split when positive and above it when negative.
+            if math.IsNaN(sample[featureNumber]) {
+                if y > 0 {
+                    errorMissingBelow += weights[j]
+                } else {
+                    errorMissingAbove += weights[j]
+                }
+                continue
+            }
+
+            // If wrongly classified, sums the sample's weight to its error.
+            if float64(classifier.Classify(sample)) != y {
+                errorMissingBelow += weights[j]
+                errorMissingAbove += weights[j]
+            }
+        }
+        categories := classifier.GetCategories()
+        *classifier = *newStumpWithLeastError(featureNumber, classifier.GetSplit(), errorMissingBelow, errorMissingAbove, totalWeight)
+        classifier.SetCategories(categories)
+
+        // Retains the classifier with minor error.
+        if classifier.GetError() < bestError {
+            bestError = classifier.GetError()
+            bestIndex = i
+        }
+    }
+    best := (*classifiers)[bestIndex]
+    return &best, nil
+}
+
+// Generates a bunch of random weak classifiers and selects the one with minimum error over sparse samples.
+// The error of each one is computed from the column of its feature.
+func (w *StumpLearner) selectBestRandomSparseClassifier(samples *utils.SparseSamples, weights []float64, numberOfFeatures uint) (WeakClassifier, error) {
+    featuresMetrics, _, err := w.analyzer.AnalyzeSparse(samples)
+    if err != nil {
+        return nil, err
+    }
+    classifiers := w.generateRandomClassifiers(featuresMetrics, numberOfFeatures)
+    if len(*classifiers) == 0 {
+        return nil, utils.ErrDegenerateWeakClassifier
+    }
+    positiveWeight, negativeWeight := sumSparseClassWeights(samples, weights)
+    totalWeight := positiveWeight + negativeWeight
+
+    var bestIndex int
+    bestError := math.MaxFloat64
+    for i := range *classifiers {
+        classifier := &(*classifiers)[i]
+        featureNumber := classifier.GetFeatureNumber()
+        storedPositive, storedNegative := w.sumStoredClassWeights(samples, weights, featureNumber)
+
+        // The samples whose feature is 0 are all classified alike.
+        errorOfPresent := positiveWeight - storedPositive
+        if classifier.classifyValue(0) > 0 {
+            errorOfPresent = negativeWeight - storedNegative
+        }
+        rows, values, missing := w.sparsePresentAndMissing(featureNumber)
+        for j, row := range rows {
+            if float64(classifier.classifyValue(values[j])) != samples.Labels[row] {
+                errorOfPresent += weights[row]
+            }
+        }
+        positiveMissing, negativeMissing := sumRowsClassWeights(missing, weights, func(row int) float64 {
+            return samples.Labels[row]
+        })
+        categories := classifier.GetCategories()
+        *classifier = *newStumpWithLeastError(featureNumber, classifier.GetSplit(), errorOfPresent + positiveMissing, errorOfPresent + negativeMissing, totalWeight)
+        classifier.SetCategories(categories)
+        if classifier.GetError() < bestError {
+            bestError = classifier.GetError()
+            bestIndex = i
+        }
+    }
+    best := (*classifiers)[bestIndex]
+    return &best, nil
+}
+
+// Generates a bunch of random weak classifiers, choosing their splits within the range of their features. Categorical
+// features missing from every sample have no categories to split, and are not chosen.
+func (w *StumpLearner) generateRandomClassifiers(featuresMetrics []statistics.FeatureStatistic, numberOfFeatures uint) *[]Stump {
+
+    var classifiers []Stump
+    featureNumbers := []uint{}
+    for featureNumber := uint(0); featureNumber < numberOfFeatures; featureNumber++ {
+        if column := w.categories[featureNumber]; column == nil || len(column.values) > 0 {
+            featureNumbers = append(featureNumbers, featureNumber)
+        }
+    }
+    if len(featureNumbers) == 0 {
+        return &classifiers
+    }
+
+    // Creates numberOfRandomClassifiers random classifiers.
+    for i := 0; i < w.numberOfRandomClassifiers; i++ {
+
+        // Random feature number.
+        featureNumber := featureNumbers[rand.Intn(len(featureNumbers))]
+
+        // Gets info about the feature.
+        info := featuresMetrics[featureNumber]
+
+        // Categorical features get a random subset of their categories.
+        if column := w.categories[featureNumber]; column != nil {
+            categories := []float64{}
+            for _, category := range column.values {
+                if rand.Intn(2) == 0 {
+                    categories = append(categories, category)
+                }
+            }
+            if len(categories) == 0 {
+                categories = append(categories, column.values[rand.Intn(len(column.values))])
+            }
+            stump := NewStump(featureNumber, 0)
+            stump.SetCategories(categories)
+            classifiers = append(classifiers, *stump)
+            continue
+        }
+
+        // Use the info to randomly choose the split value.
+        split := (rand.Float64() * info.Rng) + info.Min
+
+        // Creates and append the random classifier into the list.
+        classifiers = append(classifiers, *NewStump(featureNumber, split))
+    }
+    return &classifiers
+}
@@ -0,0 +1,275 @@
+package classifier
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+    "math"
+    "math/rand"
+    "reflect"
+    "sort"
+    "testing"
+)
+
+// Generates samples with the given number of features, each taking one of levels values, and labels -1 or 1 mostly
+// following the first feature. A fraction zeros of the values are 0 and a fraction missing of them are NaN.
+func randomSamples(random *rand.Rand, numberOfSamples, numberOfFeatures, levels int, zeros, missing float64) [][]float64 {
+    samples := make([][]float64, numberOfSamples)
+    for i := range samples {
+        sample := make([]float64, numberOfFeatures + 1)
+        for j := 0; j < numberOfFeatures; j++ {
+            switch r := random.Float64(); {
+            case r < zeros:
+                sample[j] = 0
+            case r < zeros + missing:
+                sample[j] = math.NaN()
+            default:
+                sample[j] = float64(random.Intn(levels) - levels / 2)
+            }
+        }
+        sample[numberOfFeatures] = -1
+        if (sample[0] > 0) != (random.Float64() < 0.2) {
+            sample[numberOfFeatures] = 1
+        }
+        samples[i] = sample
+    }
+    return samples
+}
+
+// Draws a distribution over the samples.
+func randomWeights(random *rand.Rand, numberOfSamples int) []float64 {
+    weights := make([]float64, numberOfSamples)
+    sum := 0.0
+    for i := range weights {
+        weights[i] = random.Float64() + 0.01
+        sum += weights[i]
+    }
+    for i := range weights {
+        weights[i] /= sum
+    }
+    return weights
+}
+
+// Weighted error of a weak classifier over the samples.
+func weightedError(weakClassifier WeakClassifier, samples [][]float64, weights []float64) float64 {
+    error := 0.0
+    for i, sample := range samples {
+        if float64(weakClassifier.Classify(sample)) != sample[len(sample) - 1] {
+            error += weights[i]
+        }
+    }
+    return error
+}
+
+// Finds the least error of all stumps by trying every value of every feature as split, with both polarities and
+// both sides for the missing values.
+func bruteForceStumpError(samples [][]float64, weights []float64) float64 {
+    best := math.Inf(1)
+    for featureNumber := 0; featureNumber < len(samples[0]) - 1; featureNumber++ {
+        for _, sample := range samples {
+            split := sample[featureNumber]
+            if math.IsNaN(split) {
+                continue
+            }
+            for _, polarity := range []int{1, -1} {
+                for _, missingAbove := range []bool{false, true} {
+                    stump := NewStumpWithPolarity(uint(featureNumber), split, polarity)
+                    stump.SetMissingAbove(missingAbove)
+                    best = math.Min(best, weightedError(stump, samples, weights))
+                }
+            }
+        }
+    }
+    return best
+}
+
+func TestStumpLearnerMatchesBruteForce(t *testing.T) {
+    tests := []struct {
+        name             string
+        numberOfSamples  int
+        numberOfFeatures int
+        levels           int
+        zeros            float64
+        missing          float64
+    }{
+        {"few distinct values", 50, 3, 3, 0, 0},
+        {"many distinct values", 80, 4, 100, 0, 0},
+        {"single feature", 30, 1, 10, 0, 0},
+        {"mostly zeros", 60, 5, 7, 0.7, 0},
+        {"missing values", 60, 3, 8, 0, 0.2},
+        {"mostly missing values", 40, 2, 5, 0.1, 0.7},
+    }
+    for seed, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            random := rand.New(rand.NewSource(int64(seed)))
+            samples := randomSamples(random, test.numberOfSamples, test.numberOfFeatures, test.levels, test.zeros, test.missing)
+            weights := randomWeights(random, len(samples))
+
+            weakClassifier, err := NewStumpLearner(DefaultOptions()).GenerateWeakClassifier(samples, weights)
+            if err != nil {
+                t.Fatal(err)
+            }
+            want := bruteForceStumpError(samples, weights)
+            if got := weakClassifier.GetError(); math.Abs(got - want) > 1e-12 {
+                t.Errorf("error is %v, want %v", got, want)
+            }
+            if got := weightedError(weakClassifier, samples, weights); math.Abs(got - weakClassifier.GetError()) > 1e-12 {
+                t.Errorf("stump misclassifies %v of the weight but reports an error of %v", got, weakClassifier.GetError())
+            }
+        })
+    }
+}
+
+// Checks the weak classifiers learned from the dense and the sparse samples have the same error, up to rounding, and
+// classify the same way when they split the same features. Stumps tying up to rounding may be picked by either one.
+func checkSameWeakClassifier(t *testing.T, dense, sparse WeakClassifier, samples [][]float64) {
+    t.Helper()
+    if dense.Kind() != sparse.Kind() {
+        t.Fatalf("dense learned a %s, sparse learned a %s", dense.Kind(), sparse.Kind())
+    }
+
+    // Z_{t} takes square roots of sums of weights that may only differ by rounding, so it is only as close as the
+    // square root of the rounding error.
+    if math.Abs(dense.GetError() - sparse.GetError()) > 1e-7 {
+        t.Fatalf("dense learned %v, sparse learned %v", dense, sparse)
+    }
+    if !reflect.DeepEqual(dense.GetFeatureNumbers(), sparse.GetFeatureNumbers()) {
+        return
+    }
+    dense.ComputeAlpha()
+    sparse.ComputeAlpha()
+    for i, sample := range samples {
+        if got, want := sparse.ClassifyWithAlpha(sample), dense.ClassifyWithAlpha(sample); math.Abs(got - want) > 1e-6 {
+            t.Fatalf("sample %d: sparse gives %v, dense gives %v", i, got, want)
+        }
+    }
+}
+
+var sparseTests = []struct {
+    name             string
+    numberOfSamples  int
+    numberOfFeatures int
+    levels           int
+    zeros            float64
+    missing          float64
+}{
+    {"dense values", 50, 3, 5, 0.1, 0},
+    {"mostly zeros", 80, 6, 9, 0.8, 0},
+    {"zeros and two values", 40, 4, 3, 0.5, 0},
+    {"negative and positive values", 70, 2, 40, 0.3, 0},
+    {"missing values", 60, 4, 6, 0.3, 0.2},
+    {"mostly missing values", 50, 3, 5, 0.2, 0.6},
+}
+
+func TestStumpLearnerSparseMatchesDense(t *testing.T) {
+    for seed, test := range sparseTests {
+        t.Run(test.name, func(t *testing.T) {
+            random := rand.New(rand.NewSource(int64(seed)))
+            samples := randomSamples(random, test.numberOfSamples, test.numberOfFeatures, test.levels, test.zeros, test.missing)
+            weights := randomWeights(random, len(samples))
+
+            dense, err := NewStumpLearner(DefaultOptions()).GenerateWeakClassifier(samples, weights)
+            if err != nil {
+                t.Fatal(err)
+            }
+            sparse, err := NewStumpLearner(DefaultOptions()).GenerateSparseWeakClassifier(utils.NewSparseSamplesFromDense(samples), weights)
+            if err != nil {
+                t.Fatal(err)
+            }
+            checkSameWeakClassifier(t, dense, sparse, samples)
+        })
+    }
+}
+
+var categoricalTests = []struct {
+    name             string
+    numberOfSamples  int
+    numberOfFeatures int
+    levels           int
+    zeros            float64
+    missing          float64
+}{
+    {"three categories", 40, 2, 3, 0, 0},
+    {"six categories", 80, 3, 6, 0, 0},
+    {"mostly zeros", 60, 3, 5, 0.6, 0},
+    {"missing values", 70, 2, 5, 0.1, 0.3},
+}
+
+// Finds the least error of all categorical stumps by trying every subset of the categories of every feature, except
+// the empty one and the one holding them all, with both polarities and both sides for the missing values.
+func bruteForceCategoricalStumpError(samples [][]float64, weights []float64) float64 {
+    best := math.Inf(1)
+    for featureNumber := 0; featureNumber < len(samples[0]) - 1; featureNumber++ {
+        var categories []float64
+        seen := make(map[float64]bool)
+        for _, sample := range samples {
+            if value := sample[featureNumber]; !math.IsNaN(value) && !seen[value] {
+                seen[value] = true
+                categories = append(categories, value)
+            }
+        }
+        sort.Float64s(categories)
+        for subset := 1; subset < 1 << uint(len(categories)) - 1; subset++ {
+            var above []float64
+            for i, category := range categories {
+                if subset & (1 << uint(i)) != 0 {
+                    above = append(above, category)
+                }
+            }
+            for _, polarity := range []int{1, -1} {
+                for _, missingAbove := range []bool{false, true} {
+                    stump := NewStumpWithPolarity(uint(featureNumber), 0, polarity)
+                    stump.SetCategories(above)
+                    stump.SetMissingAbove(missingAbove)
+                    best = math.Min(best, weightedError(stump, samples, weights))
+                }
+            }
+        }
+    }
+    return best
+}
+
+// Checks a categorical weak classifier holds some of the categories of its feature, but not all of them.
+func checkProperSubset(t *testing.T, categories []float64, samples [][]float64, featureNumber uint) {
+    t.Helper()
+    if len(categories) == 0 {
+        t.Fatalf("categorical split of feature %d has no categories", featureNumber)
+    }
+    for _, sample := range samples {
+        if value := sample[featureNumber]; !math.IsNaN(value) && !containsCategory(categories, value) {
+            return
+        }
+    }
+    t.Fatalf("categorical split of feature %d holds all its categories %v", featureNumber, categories)
+}
+
+func TestStumpLearnerCategorical(t *testing.T) {
+    for seed, test := range categoricalTests {
+        t.Run(test.name, func(t *testing.T) {
+            random := rand.New(rand.NewSource(int64(seed)))
+            samples := randomSamples(random, test.numberOfSamples, test.numberOfFeatures, test.levels, test.zeros, test.missing)
+            weights := randomWeights(random, len(samples))
+            options := DefaultOptions()
+            for featureNumber := 0; featureNumber < test.numberOfFeatures; featureNumber++ {
+                options.CategoricalFeatures = append(options.CategoricalFeatures, uint(featureNumber))
+            }
+
+            dense, err := NewStumpLearner(options).GenerateWeakClassifier(samples, weights)
+            if err != nil {
+                t.Fatal(err)
+            }
+            stump := dense.(*Stump)
+            checkProperSubset(t, stump.GetCategories(), samples, stump.GetFeatureNumber())
+            if want := bruteForceCategoricalStumpError(samples, weights); math.Abs(stump.GetError() - want) > 1e-12 {
+                t.Errorf("error is %v, want %v", stump.GetError(), want)
+            }
+            if got := weightedError(stump, samples, weights); math.Abs(got - stump.GetError()) > 1e-12 {
+                t.Errorf("stump misclassifies %v of the weight but reports an error of %v", got, stump.GetError())
+            }
+
+            sparse, err := NewStumpLearner(options).GenerateSparseWeakClassifier(utils.NewSparseSamplesFromDense(samples), weights)
+            if err != nil {
+                t.Fatal(err)
+            }
+            checkSameWeakClassifier(t, dense, sparse, samples)
+        })
+    }
+}
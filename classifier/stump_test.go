@@ -0,0 +1,50 @@
+package classifier
+
+import (
+    "math"
+    "testing"
+)
+
+func TestStumpComputeAlpha(t *testing.T) {
+    tests := []struct {
+        name  string
+        error float64
+        want  float64
+    }{
+        {"separating", 0, 0.5 * math.Log((1 - MIN_CLASS_PROBABILITY) / MIN_CLASS_PROBABILITY)},
+        {"always wrong", 1, -0.5 * math.Log((1 - MIN_CLASS_PROBABILITY) / MIN_CLASS_PROBABILITY)},
+        {"guessing", 0.5, 0},
+        {"a quarter wrong", 0.25, 0.5 * math.Log(3)},
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            stump := NewStump(0, 0)
+            stump.SetError(test.error)
+            stump.ComputeAlpha()
+            if got := stump.GetAlpha(); math.IsInf(got, 0) || math.IsNaN(got) || math.Abs(got - test.want) > 1e-6 {
+                t.Errorf("alpha is %v, want %v", got, test.want)
+            }
+        })
+    }
+}
+
+// A stump separating the training set must leave the weights a distribution, so the next rounds can still be learned.
+func TestAdaBoostSeparableSamples(t *testing.T) {
+    samples := [][]float64{{1, -1}, {2, -1}, {3, -1}, {4, 1}, {5, 1}, {6, 1}}
+    options := DefaultOptions()
+    options.NumberOfClassifiers = 3
+    adaBoost := NewAdaBoost(options)
+    if err := adaBoost.Train(samples); err != nil {
+        t.Fatal(err)
+    }
+    for round, weakClassifier := range adaBoost.WeakClassifiers {
+        if alpha := weakClassifier.GetAlpha(); math.IsInf(alpha, 0) || math.IsNaN(alpha) {
+            t.Fatalf("round %d: alpha is %v", round, alpha)
+        }
+    }
+    for i, sample := range samples {
+        if score := adaBoost.Classify(sample); math.IsNaN(score) || (score > 0) != (sample[1] > 0) {
+            t.Errorf("sample %d: score is %v", i, score)
+        }
+    }
+}
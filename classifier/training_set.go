@@ -0,0 +1,126 @@
+package classifier
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/statistics"
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+)
+
+// Training set of the boosting rounds, holding dense or sparse samples.
+type trainingSet interface {
+    size() int
+    label(i int) float64
+
+    // Gets sample i as a dense vector having the label at its last position. The vector may be overwritten by the
+    // next call.
+    sample(i int) []float64
+
+    // Gets the weight of each sample given with the training set, or nil when they all weigh the same.
+    sampleWeights() []float64
+
+    // Calls the weak learner with the samples in the form it takes.
+    prepare(weakLearner WeakLearner)
+    generateWeakClassifier(weakLearner WeakLearner, weights []float64) (WeakClassifier, error)
+    generateRegressor(weakLearner WeakLearner, targets []float64, weights []float64) (WeakClassifier, error)
+}
+
+// Counts the classes of the training set as FeaturesAnalyzer does.
+func classDistribution(set trainingSet) statistics.ClassDistribution {
+    distribution := statistics.ClassDistribution{Classes: make(map[int]uint)}
+    for i := 0; i < set.size(); i++ {
+        y := set.label(i)
+        if y == -1 {
+            distribution.Negative++
+        } else {
+            distribution.Positive++
+        }
+        distribution.Classes[int(y)]++
+    }
+    return distribution
+}
+
+type denseTrainingSet struct {
+    samples [][]float64
+    weights []float64
+}
+
+func (s denseTrainingSet) size() int {
+    return len(s.samples)
+}
+
+func (s denseTrainingSet) label(i int) float64 {
+    return s.samples[i][len(s.samples[i]) - 1]
+}
+
+func (s denseTrainingSet) sample(i int) []float64 {
+    return s.samples[i]
+}
+
+func (s denseTrainingSet) sampleWeights() []float64 {
+    return s.weights
+}
+
+func (s denseTrainingSet) prepare(weakLearner WeakLearner) {
+    if preparable, ok := weakLearner.(PreparableWeakLearner); ok {
+        preparable.Prepare(s.samples)
+    }
+}
+
+func (s denseTrainingSet) generateWeakClassifier(weakLearner WeakLearner, weights []float64) (WeakClassifier, error) {
+    return weakLearner.GenerateWeakClassifier(s.samples, weights)
+}
+
+func (s denseTrainingSet) generateRegressor(weakLearner WeakLearner, targets []float64, weights []float64) (WeakClassifier, error) {
+    learner, ok := weakLearner.(RegressionLearner)
+    if !ok {
+        return nil, ErrNotRegressionLearner
+    }
+    return learner.GenerateRegressor(s.samples, targets, weights)
+}
+
+// Sparse samples, read as dense ones through a single reused vector.
+type sparseTrainingSet struct {
+    samples *utils.SparseSamples
+    buffer  *utils.RowBuffer
+}
+
+func newSparseTrainingSet(samples *utils.SparseSamples) *sparseTrainingSet {
+    return &sparseTrainingSet{samples: samples, buffer: samples.NewRowBuffer()}
+}
+
+func (s *sparseTrainingSet) size() int {
+    return s.samples.NumberOfSamples()
+}
+
+func (s *sparseTrainingSet) label(i int) float64 {
+    return s.samples.Labels[i]
+}
+
+func (s *sparseTrainingSet) sample(i int) []float64 {
+    return s.buffer.Row(i)
+}
+
+func (s *sparseTrainingSet) sampleWeights() []float64 {
+    return nil
+}
+
+func (s *sparseTrainingSet) prepare(weakLearner WeakLearner) {
+    if learner, ok := weakLearner.(SparseWeakLearner); ok {
+        learner.PrepareSparse(s.samples)
+    }
+}
+
+func (s *sparseTrainingSet) generateWeakClassifier(weakLearner WeakLearner, weights []float64) (WeakClassifier, error) {
+    learner, ok := weakLearner.(SparseWeakLearner)
+    if !ok {
+        return nil, ErrNotSparseLearner
+    }
+    return learner.GenerateSparseWeakClassifier(s.samples, weights)
+}
+
+func (s *sparseTrainingSet) generateRegressor(weakLearner WeakLearner, targets []float64, weights []float64) (WeakClassifier, error) {
+    learner, ok := weakLearner.(SparseRegressionLearner)
+    if !ok {
+        return nil, ErrNotRegressionLearner
+    }
+    return learner.GenerateSparseRegressor(s.samples, targets, weights)
+}
@@ -1,11 +1,79 @@
 package config
 
-const (
-    NUMBER_OF_RANDOM_CLASSIFIERS = 10
-    USE_RANDOM_WEAK_CLASSIFIERS = false
-    INCORPORATE_COST_SENSITIVE_LEARNING = true
-    USE_THRESHOLD_CLASSIFICATION = true
-    TEST_PERCENT = 0.4
-    NUM_OF_WEAK_CLASSIFIERS = 100
-    OVER_SAMPLING_TRAINING_SET = false
-)
\ No newline at end of file
+import (
+    "encoding/json"
+    "flag"
+    "os"
+    "github.com/dalmirdasilva/AdaBoostGo/classifier"
+)
+
+// Loads the options from a JSON file, as the ones written by SaveOptions.
+// Options missing from the file keep their default values.
+func LoadOptions(fileName string) (classifier.Options, error) {
+    options := classifier.DefaultOptions()
+    file, err := os.Open(fileName)
+    if err != nil {
+        return options, err
+    }
+    defer file.Close()
+    if err := json.NewDecoder(file).Decode(&options); err != nil {
+        return options, err
+    }
+    return options, options.Validate()
+}
+
+// Saves the options to a JSON file.
+func SaveOptions(fileName string, options classifier.Options) error {
+    b, err := json.MarshalIndent(options, "", "    ")
+    if err != nil {
+        return err
+    }
+    return os.WriteFile(fileName, b, 0644)
+}
+
+// Binds the options to flags of the given set. The current values of the options are the defaults of the flags.
+func BindFlags(flagSet *flag.FlagSet, options *classifier.Options) {
+    flagSet.Var(&modeFlag{&options.Mode}, "mode", "boosting mode: discrete, real, gentle or logit")
+    flagSet.UintVar(&options.NumberOfClassifiers, "classifiers", options.NumberOfClassifiers, "number of boosting rounds")
+    flagSet.Float64Var(&options.LearningRate, "learning-rate", options.LearningRate, "shrinkage in (0, 1] scaling the alpha of each weak classifier")
+    flagSet.BoolVar(&options.UseRandomWeakClassifiers, "random", options.UseRandomWeakClassifiers, "generate random weak classifiers")
+    flagSet.IntVar(&options.NumberOfRandomClassifiers, "random-classifiers", options.NumberOfRandomClassifiers, "number of random weak classifiers generated each round")
+    flagSet.BoolVar(&options.IncorporateCostSensitiveLearning, "cost-sensitive", options.IncorporateCostSensitiveLearning, "initialize the weights inversely proportional to the class rates")
+    flagSet.BoolVar(&options.UseThresholdClassification, "threshold", options.UseThresholdClassification, "evaluate discrete classifiers using the threshold classification")
+    flagSet.BoolVar(&options.OverSamplingTrainingSet, "over-sampling", options.OverSamplingTrainingSet, "over sample the minority class of the training set")
+    flagSet.Float64Var(&options.TestPercent, "test-percent", options.TestPercent, "fraction of the samples held out for testing")
+    flagSet.Float64Var(&options.ValidationPercent, "validation-percent", options.ValidationPercent, "fraction of the samples held out from training to stop early on")
+    flagSet.UintVar(&options.EarlyStoppingRounds, "early-stopping", options.EarlyStoppingRounds, "stop after this many rounds without improving the validation metric, 0 never stops early")
+    flagSet.Var(&stoppingMetricFlag{&options.EarlyStoppingMetric}, "stopping-metric", "validation metric to stop early on: error, log-loss or exponential-loss")
+    flagSet.IntVar(&options.NumberOfWorkers, "workers", options.NumberOfWorkers, "number of goroutines searching the weak classifiers, 0 uses one per CPU")
+}
+
+type modeFlag struct {
+    mode *classifier.BoostingMode
+}
+
+func (f *modeFlag) String() string {
+    if f.mode == nil {
+        return ""
+    }
+    return f.mode.String()
+}
+
+func (f *modeFlag) Set(value string) error {
+    return f.mode.UnmarshalText([]byte(value))
+}
+
+type stoppingMetricFlag struct {
+    metric *classifier.StoppingMetric
+}
+
+func (f *stoppingMetricFlag) String() string {
+    if f.metric == nil {
+        return ""
+    }
+    return f.metric.String()
+}
+
+func (f *stoppingMetricFlag) Set(value string) error {
+    return f.metric.UnmarshalText([]byte(value))
+}
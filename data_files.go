@@ -0,0 +1,239 @@
+package main
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/classifier"
+    "github.com/dalmirdasilva/AdaBoostGo/evaluation"
+    "github.com/dalmirdasilva/AdaBoostGo/preprocessing"
+    "github.com/dalmirdasilva/AdaBoostGo/statistics"
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+    "path/filepath"
+    "math/rand"
+    "errors"
+    "strconv"
+    "strings"
+    "flag"
+    "fmt"
+)
+
+// Extensions of the LIBSVM (SVMlight) files. Files with other extensions are read as CSV.
+var libSVMExtensions = map[string]bool{".libsvm": true, ".svm": true, ".svmlight": true}
+
+// Flags telling how the columns of a CSV file make a dataset.
+type datasetFlags struct {
+    header        *string
+    delimiter     *string
+    missingValues *string
+    raggedRows    *string
+    labelColumn   *int
+    idColumn      *int
+    weightColumn  *int
+    ignoreColumns *string
+}
+
+func bindDatasetFlags(flagSet *flag.FlagSet) datasetFlags {
+    return datasetFlags{
+        header: flagSet.String("header", "detect", "whether the first row of a CSV file holds the column names, naming the features: yes, no, or detect when it is not numeric"),
+        delimiter: flagSet.String("delimiter", ",", "delimiter of the cells of a CSV file"),
+        missingValues: flagSet.String("missing", "", "comma separated values meaning a missing feature in a CSV file, a trailing comma adding the empty cell"),
+        raggedRows: flagSet.String("ragged", "reject", "what to do with the rows of a CSV file with a different number of columns: reject, pad shorter rows with missing values, or skip"),
+        labelColumn: flagSet.Int("label-column", -1, "index of the label column of a CSV file, the last one when negative"),
+        idColumn: flagSet.Int("id-column", -1, "index of the sample ID column of a CSV file, none when negative"),
+        weightColumn: flagSet.Int("weight-column", -1, "index of the sample weight column of a CSV file, none when negative"),
+        ignoreColumns: flagSet.String("ignore-columns", "", "comma separated indexes of the columns of a CSV file that are not read"),
+    }
+}
+
+// Gets the dataset options of the parsed flags.
+func (f datasetFlags) options() (utils.DatasetOptions, error) {
+    options := utils.DefaultDatasetOptions()
+    var err error
+    if options.Header, err = utils.ParseHeaderPolicy(*f.header); err != nil {
+        return options, err
+    }
+    if options.RaggedRows, err = utils.ParseRaggedRowPolicy(*f.raggedRows); err != nil {
+        return options, err
+    }
+    delimiter := []rune(*f.delimiter)
+    if len(delimiter) != 1 {
+        return options, fmt.Errorf("the delimiter must be a single character, got %q", *f.delimiter)
+    }
+    options.Delimiter = delimiter[0]
+    if *f.missingValues != "" {
+        options.MissingValues = strings.Split(*f.missingValues, ",")
+    }
+    options.LabelColumn = *f.labelColumn
+    options.IDColumn = *f.idColumn
+    options.WeightColumn = *f.weightColumn
+    ignoredColumns, err := parseColumns(*f.ignoreColumns)
+    if err != nil {
+        return options, err
+    }
+    for column := range ignoredColumns {
+        options.IgnoredColumns = append(options.IgnoredColumns, column)
+    }
+    return options, nil
+}
+
+// Samples read by the commands. Samples of CSV files are dense and those of LIBSVM files are kept sparse.
+type dataset struct {
+    dense  *utils.Dataset
+    sparse *utils.SparseSamples
+
+    // Summary of the loaded CSV file, nil for LIBSVM files.
+    summary *utils.LoadSummary
+}
+
+// Reads the samples of a CSV or LIBSVM file. The LIBSVM samples have numberOfFeatures features, or as many as the
+// largest index found when 0, and ignore the options.
+func readDataset(fileName string, numberOfFeatures uint, options utils.DatasetOptions) (dataset, error) {
+    if libSVMExtensions[filepath.Ext(fileName)] {
+        samples, err := utils.ReadLIBSVMSparseSamples(fileName, int(numberOfFeatures))
+        return dataset{sparse: samples}, err
+    }
+    samples, summary, err := utils.ReadDataset(fileName, options)
+    return dataset{dense: samples, summary: &summary}, err
+}
+
+func (d dataset) validate() error {
+    if d.sparse != nil {
+        return d.sparse.Validate()
+    }
+    return d.dense.Validate()
+}
+
+func (d dataset) size() int {
+    if d.sparse != nil {
+        return d.sparse.NumberOfSamples()
+    }
+    return d.dense.NumberOfSamples()
+}
+
+// Gets the number of features of the validated samples.
+func (d dataset) numberOfFeatures() uint {
+    if d.sparse != nil {
+        return uint(d.sparse.NumberOfFeatures)
+    }
+    return uint(d.dense.NumberOfFeatures())
+}
+
+// Gets the feature names, nil when the file has none.
+func (d dataset) featureNames() []string {
+    if d.sparse != nil {
+        return nil
+    }
+    return d.dense.FeatureNames
+}
+
+// Gets the numbers of comma separated features, given by name or number.
+func (d dataset) parseFeatures(value string) ([]uint, error) {
+    names := map[string]uint{}
+    for featureNumber, name := range d.featureNames() {
+        names[name] = uint(featureNumber)
+    }
+    featureNumbers := []uint{}
+    for _, feature := range strings.Split(value, ",") {
+        feature = strings.TrimSpace(feature)
+        if featureNumber, ok := names[feature]; ok {
+            featureNumbers = append(featureNumbers, featureNumber)
+            continue
+        }
+        featureNumber, err := strconv.ParseUint(feature, 10, 0)
+        if err != nil {
+            return nil, fmt.Errorf("unknown feature %q", feature)
+        }
+        featureNumbers = append(featureNumbers, uint(featureNumber))
+    }
+    return featureNumbers, nil
+}
+
+func (d *dataset) shuffle() {
+    if d.sparse != nil {
+        d.sparse = d.sparse.Select(rand.Perm(d.sparse.NumberOfSamples()))
+        return
+    }
+    d.dense = d.dense.Select(rand.Perm(d.dense.NumberOfSamples()))
+}
+
+// Splits the samples into the first n and the rest.
+func (d dataset) split(n int) (dataset, dataset) {
+    if d.sparse != nil {
+        head := make([]int, n)
+        tail := make([]int, d.size() - n)
+        for i := range head {
+            head[i] = i
+        }
+        for i := range tail {
+            tail[i] = n + i
+        }
+        return dataset{sparse: d.sparse.Select(head)}, dataset{sparse: d.sparse.Select(tail)}
+    }
+    head, tail := d.dense.Split(n)
+    return dataset{dense: head}, dataset{dense: tail}
+}
+
+// Fits a preprocessing pipeline on the samples, encoding their category columns in order with the given encoders, and
+// gets the encoded samples.
+func (d dataset) fitPipeline(encoders []preprocessing.Encoder, random *rand.Rand) (*preprocessing.Pipeline, dataset, error) {
+    if d.sparse != nil {
+        return nil, d, errors.New("LIBSVM files have no category columns to encode")
+    }
+    pipeline, encoded, err := preprocessing.FitPipeline(d.dense, encoders, random)
+    return pipeline, dataset{dense: encoded, summary: d.summary}, err
+}
+
+// Encodes the category columns of the samples with a fitted pipeline.
+func (d dataset) transform(pipeline *preprocessing.Pipeline) (dataset, error) {
+    if d.sparse != nil {
+        return d, errors.New("the model encodes category columns, which LIBSVM files do not have")
+    }
+    encoded, err := pipeline.Transform(d.dense)
+    return dataset{dense: encoded, summary: d.summary}, err
+}
+
+// Sets the samples as the validation set of an AdaBoost, to stop early on.
+func (d dataset) setValidationSet(adaBoost *classifier.AdaBoost) error {
+    if d.sparse != nil {
+        return adaBoost.SetValidationSparse(d.sparse)
+    }
+    return adaBoost.SetValidationDataset(d.dense)
+}
+
+func (d dataset) train(adaBoost *classifier.AdaBoost) error {
+    if d.sparse != nil {
+        return adaBoost.TrainSparse(d.sparse)
+    }
+    return adaBoost.TrainDataset(d.dense)
+}
+
+func (d dataset) classDistribution() (statistics.ClassDistribution, error) {
+    analyzer := statistics.NewFeaturesAnalyzer()
+    var distribution statistics.ClassDistribution
+    var err error
+    if d.sparse != nil {
+        _, distribution, err = analyzer.AnalyzeSparse(d.sparse)
+    } else {
+        _, distribution, err = analyzer.Analyze(d.dense.Samples)
+    }
+    return distribution, err
+}
+
+func (d dataset) evaluate(evaluator *evaluation.Evaluator) (statistics.ContingencyTable, error) {
+    if d.sparse != nil {
+        return evaluator.EvaluateSparse(d.sparse)
+    }
+    return evaluator.EvaluateDataset(d.dense)
+}
+
+func (d dataset) logLoss(evaluator *evaluation.Evaluator) (float64, error) {
+    if d.sparse != nil {
+        return evaluator.LogLossSparse(d.sparse)
+    }
+    return evaluator.LogLoss(d.dense.Samples)
+}
+
+func (d dataset) brierScore(evaluator *evaluation.Evaluator) (float64, error) {
+    if d.sparse != nil {
+        return evaluator.BrierScoreSparse(d.sparse)
+    }
+    return evaluator.BrierScore(d.dense.Samples)
+}
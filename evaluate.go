@@ -0,0 +1,76 @@
+package main
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/evaluation"
+    "errors"
+    "sort"
+    "fmt"
+)
+
+// Scores a labelled file against a saved model.
+func runEvaluate(args []string) error {
+    flagSet := newFlagSet("evaluate", "data.csv|data.libsvm")
+    modelFilePath := flagSet.String("model", "", "file to read the model from")
+    format := flagSet.String("format", "", "model format: "+MODEL_FORMAT_USAGE)
+    datasetFlags := bindDatasetFlags(flagSet)
+    if err := parseArguments(flagSet, args, 1); err != nil {
+        return err
+    }
+    if *modelFilePath == "" {
+        return errors.New("the -model flag is needed")
+    }
+
+    model, err := loadModel(*modelFilePath, *format)
+    if err != nil {
+        return err
+    }
+    datasetOptions, err := datasetFlags.options()
+    if err != nil {
+        return err
+    }
+    if model.Pipeline != nil {
+        datasetOptions.CategoryInputs = model.Pipeline.CategoryInputs()
+    }
+    samples, err := readDataset(flagSet.Arg(0), model.NumberOfFeatures, datasetOptions)
+    if err != nil {
+        return err
+    }
+    if samples.summary != nil {
+        fmt.Println(samples.summary.String())
+    }
+    if model.Pipeline != nil {
+        if err := samples.validate(); err != nil {
+            return err
+        }
+        if samples, err = samples.transform(model.Pipeline); err != nil {
+            return err
+        }
+    }
+    if err := checkNumberOfFeatures(samples, model); err != nil {
+        return err
+    }
+
+    evaluator := evaluation.NewEvaluator(&model.AdaBoost, model.AdaBoost.GetOptions())
+    contingencyTable, err := samples.evaluate(&evaluator)
+    if err != nil {
+        return err
+    }
+    logLoss, err := samples.logLoss(&evaluator)
+    if err != nil {
+        return err
+    }
+    brierScore, err := samples.brierScore(&evaluator)
+    if err != nil {
+        return err
+    }
+    fmt.Println(contingencyTable.String())
+    fmt.Printf("Log loss: %f\n", logLoss)
+    fmt.Printf("Brier score: %f\n", brierScore)
+    featureOccurrences := []string{}
+    for featureNumber, occurrences := range evaluator.GetFeatureOccurrences() {
+        featureOccurrences = append(featureOccurrences, fmt.Sprintf("%s:%d", model.FeatureName(featureNumber), occurrences))
+    }
+    sort.Strings(featureOccurrences)
+    fmt.Printf("Feature occurrences: %v\n", featureOccurrences)
+    return nil
+}
@@ -2,37 +2,82 @@ package evaluation
 
 import (
     "github.com/dalmirdasilva/AdaBoostGo/classifier"
-    "github.com/dalmirdasilva/AdaBoostGo/config"
     "github.com/dalmirdasilva/AdaBoostGo/statistics"
     "github.com/dalmirdasilva/AdaBoostGo/utils"
     "math"
 )
 
 type Evaluator struct {
-    classifier       *classifier.AdaBoost
-    contingencyTable statistics.ContingencyTable
-    threshold        float64
+    classifier                 *classifier.AdaBoost
+    contingencyTable           statistics.ContingencyTable
+    threshold                  float64
+    useThresholdClassification bool
 }
 
-func NewEvaluator(classifier *classifier.AdaBoost) Evaluator {
-    return Evaluator{classifier: classifier, threshold: math.MaxFloat64}
+func NewEvaluator(adaBoost *classifier.AdaBoost, options classifier.Options) Evaluator {
+    return Evaluator{classifier: adaBoost, threshold: math.MaxFloat64, useThresholdClassification: options.UseThresholdClassification}
 }
 
 // Calculates the confusion matrix for a classifier and a test set.
-func (e *Evaluator) Evaluate(testSet [][]float64) statistics.ContingencyTable {
+// The threshold classification only applies to discrete classifiers, the others are classified by the score sign.
+func (e *Evaluator) Evaluate(testSet [][]float64) (statistics.ContingencyTable, error) {
+    if err := validateBinarySamples(testSet); err != nil {
+        return statistics.ContingencyTable{}, err
+    }
     e.contingencyTable = statistics.NewContingencyTable()
     for _, sample := range testSet {
-        y := int(sample[len(sample) - 1])
-        var h int
-        if config.USE_THRESHOLD_CLASSIFICATION {
-            h = e.classifyUsingThreshold(sample)
-        } else {
-            h = e.classifyNormally(sample)
-        }
-        e.contingencyTable.AddPrediction(y, h)
+        e.addPrediction(sample)
+    }
+    return e.contingencyTable, nil
+}
+
+// Calculates the confusion matrix for a classifier and a sparse test set, as Evaluate does.
+func (e *Evaluator) EvaluateSparse(testSet *utils.SparseSamples) (statistics.ContingencyTable, error) {
+    if err := validateSparseBinarySamples(testSet); err != nil {
+        return statistics.ContingencyTable{}, err
+    }
+    e.contingencyTable = statistics.NewContingencyTable()
+    buffer := testSet.NewRowBuffer()
+    for i := 0; i < testSet.NumberOfSamples(); i++ {
+        e.addPrediction(buffer.Row(i))
+    }
+    return e.contingencyTable, nil
+}
+
+// Calculates the confusion matrix for a classifier and the samples of a dataset, as Evaluate does.
+// The weights of the samples are not taken into account.
+func (e *Evaluator) EvaluateDataset(dataset *utils.Dataset) (statistics.ContingencyTable, error) {
+    if err := dataset.Validate(); err != nil {
+        return statistics.ContingencyTable{}, err
+    }
+    return e.Evaluate(dataset.Samples)
+}
+
+func (e *Evaluator) addPrediction(sample []float64) {
+    y := int(sample[len(sample) - 1])
+    var h int
+    if e.useThresholdClassification && e.classifier.GetMode() == classifier.DISCRETE_ADABOOST {
+        h = e.classifyUsingThreshold(sample)
+    } else {
+        h = e.classifyNormally(sample)
+    }
+    e.contingencyTable.AddPrediction(y, h)
+}
+
+// Test samples must carry their class, -1 or 1, in the last position.
+func validateBinarySamples(testSet [][]float64) error {
+    if err := utils.ValidateSamples(testSet, 1); err != nil {
+        return err
+    }
+    return utils.ValidateBinaryLabels(testSet, 1)
+}
+
+func validateSparseBinarySamples(testSet *utils.SparseSamples) error {
+    if err := testSet.Validate(); err != nil {
+        return err
     }
-    return e.contingencyTable
-};
+    return testSet.ValidateBinaryLabels()
+}
 
 // Computes the threshold for a classifier.
 func (e *Evaluator) getThreshold() float64 {
@@ -46,10 +91,11 @@ func (e *Evaluator) getThreshold() float64 {
 }
 
 // Classify a sample using threshold.
+// Each weak classifier votes with its alpha when it classifies the sample as positive, honouring its polarity.
 func (e *Evaluator) classifyUsingThreshold(sample []float64) int {
     score := 0.0
     for _, weakClassifier := range e.classifier.WeakClassifiers {
-        if sample[weakClassifier.GetFeatureNumber()] > weakClassifier.GetSplit() {
+        if weakClassifier.Classify(sample) > 0 {
             score += weakClassifier.GetAlpha()
         }
     }
@@ -71,7 +117,7 @@ func (e *Evaluator) classifyNormally(sample []float64) int {
 func (e *Evaluator) GetUsedFeatureNumbers(unique bool) []uint {
     var usedFeatureNumbers []uint
     for _, weakClassifier := range e.classifier.WeakClassifiers {
-        usedFeatureNumbers = append(usedFeatureNumbers, weakClassifier.GetFeatureNumber())
+        usedFeatureNumbers = append(usedFeatureNumbers, weakClassifier.GetFeatureNumbers()...)
     }
     if unique {
         return utils.RemoveDuplicates(usedFeatureNumbers)
@@ -88,3 +134,77 @@ func (e *Evaluator) GetFeatureOccurrences() map[uint]uint {
     }
     return occurrences
 }
+
+// Gets the map of feature occurrences of the classifier keyed by the feature names of a dataset.
+func (e *Evaluator) GetNamedFeatureOccurrences(dataset *utils.Dataset) map[string]uint {
+    occurrences := make(map[string]uint)
+    for featureNumber, occurrence := range e.GetFeatureOccurrences() {
+        occurrences[dataset.FeatureName(featureNumber)] = occurrence
+    }
+    return occurrences
+}
+
+// Computes the mean log loss of the probabilities predicted by the classifier over a test set.
+// Computes the following equation:
+// -\frac{1}{m}\sum_{i=1}^{m}y^{*}_{i}\ln(p_{i}) + (1 - y^{*}_{i})\ln(1 - p_{i})
+func (e *Evaluator) LogLoss(testSet [][]float64) (float64, error) {
+    if err := validateBinarySamples(testSet); err != nil {
+        return 0, err
+    }
+    sum := 0.0
+    for _, sample := range testSet {
+        sum += e.logLoss(sample)
+    }
+    return sum / float64(len(testSet)), nil
+}
+
+// Computes the mean log loss over a sparse test set, as LogLoss does.
+func (e *Evaluator) LogLossSparse(testSet *utils.SparseSamples) (float64, error) {
+    if err := validateSparseBinarySamples(testSet); err != nil {
+        return 0, err
+    }
+    sum := 0.0
+    buffer := testSet.NewRowBuffer()
+    for i := 0; i < testSet.NumberOfSamples(); i++ {
+        sum += e.logLoss(buffer.Row(i))
+    }
+    return sum / float64(testSet.NumberOfSamples()), nil
+}
+
+func (e *Evaluator) logLoss(sample []float64) float64 {
+    p := math.Max(math.Min(e.classifier.PredictProba(sample), 1 - 1e-15), 1e-15)
+    if sample[len(sample) - 1] > 0 {
+        return -math.Log(p)
+    }
+    return -math.Log(1 - p)
+}
+
+// Computes the Brier score, the mean squared difference between the predicted probabilities and the outcomes.
+func (e *Evaluator) BrierScore(testSet [][]float64) (float64, error) {
+    if err := validateBinarySamples(testSet); err != nil {
+        return 0, err
+    }
+    sum := 0.0
+    for _, sample := range testSet {
+        sum += e.squaredError(sample)
+    }
+    return sum / float64(len(testSet)), nil
+}
+
+// Computes the Brier score over a sparse test set, as BrierScore does.
+func (e *Evaluator) BrierScoreSparse(testSet *utils.SparseSamples) (float64, error) {
+    if err := validateSparseBinarySamples(testSet); err != nil {
+        return 0, err
+    }
+    sum := 0.0
+    buffer := testSet.NewRowBuffer()
+    for i := 0; i < testSet.NumberOfSamples(); i++ {
+        sum += e.squaredError(buffer.Row(i))
+    }
+    return sum / float64(testSet.NumberOfSamples()), nil
+}
+
+func (e *Evaluator) squaredError(sample []float64) float64 {
+    y := (sample[len(sample) - 1] + 1) / 2
+    return math.Pow(e.classifier.PredictProba(sample) - y, 2)
+}
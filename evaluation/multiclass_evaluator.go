@@ -0,0 +1,38 @@
+package evaluation
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/classifier"
+    "github.com/dalmirdasilva/AdaBoostGo/statistics"
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+)
+
+type MultiClassEvaluator struct {
+    classifier      *classifier.MultiClassAdaBoost
+    confusionMatrix statistics.ConfusionMatrix
+}
+
+func NewMultiClassEvaluator(classifier *classifier.MultiClassAdaBoost) MultiClassEvaluator {
+    return MultiClassEvaluator{classifier: classifier}
+}
+
+// Calculates the K×K confusion matrix for a classifier and a test set.
+func (e *MultiClassEvaluator) Evaluate(testSet [][]float64) (statistics.ConfusionMatrix, error) {
+    if err := utils.ValidateSamples(testSet, 1); err != nil {
+        return statistics.ConfusionMatrix{}, err
+    }
+    e.confusionMatrix = statistics.NewConfusionMatrix(e.classifier.Classes)
+    for _, sample := range testSet {
+        y := int(sample[len(sample) - 1])
+        e.confusionMatrix.AddPrediction(y, e.classifier.Classify(sample))
+    }
+    return e.confusionMatrix, nil
+}
+
+// Gets the map of feature number occurrences of the classifier.
+func (e *MultiClassEvaluator) GetFeatureOccurrences() map[uint]uint {
+    occurrences := make(map[uint]uint)
+    for _, weakClassifier := range e.classifier.WeakClassifiers {
+        occurrences[weakClassifier.GetFeatureNumber()]++
+    }
+    return occurrences
+}
@@ -0,0 +1,37 @@
+package evaluation
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/classifier"
+    "github.com/dalmirdasilva/AdaBoostGo/statistics"
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+)
+
+type MultiLabelEvaluator struct {
+    classifier *classifier.AdaBoostMH
+    table      statistics.MultiLabelTable
+}
+
+func NewMultiLabelEvaluator(classifier *classifier.AdaBoostMH) MultiLabelEvaluator {
+    return MultiLabelEvaluator{classifier: classifier}
+}
+
+// Calculates the Hamming loss, subset accuracy and per label contingency tables for a classifier and a test set.
+// The test samples carry their labels in the last L positions.
+func (e *MultiLabelEvaluator) Evaluate(testSet [][]float64) (statistics.MultiLabelTable, error) {
+    numberOfLabels := e.classifier.GetNumberOfLabels()
+    if err := utils.ValidateSamples(testSet, int(numberOfLabels)); err != nil {
+        return statistics.MultiLabelTable{}, err
+    }
+    if err := utils.ValidateBinaryLabels(testSet, int(numberOfLabels)); err != nil {
+        return statistics.MultiLabelTable{}, err
+    }
+    e.table = statistics.NewMultiLabelTable(numberOfLabels)
+    for _, sample := range testSet {
+        y := make([]int, numberOfLabels)
+        for l, label := range sample[len(sample) - int(numberOfLabels):] {
+            y[l] = int(label)
+        }
+        e.table.AddPrediction(y, e.classifier.Classify(sample))
+    }
+    return e.table, nil
+}
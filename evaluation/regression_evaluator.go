@@ -0,0 +1,28 @@
+package evaluation
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/classifier"
+    "github.com/dalmirdasilva/AdaBoostGo/statistics"
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+)
+
+type RegressionEvaluator struct {
+    regressor *classifier.AdaBoostR2
+    errors    statistics.RegressionErrors
+}
+
+func NewRegressionEvaluator(regressor *classifier.AdaBoostR2) RegressionEvaluator {
+    return RegressionEvaluator{regressor: regressor}
+}
+
+// Calculates the RMSE, MAE and R² of a regressor over a test set.
+func (e *RegressionEvaluator) Evaluate(testSet [][]float64) (statistics.RegressionErrors, error) {
+    if err := utils.ValidateSamples(testSet, 1); err != nil {
+        return statistics.RegressionErrors{}, err
+    }
+    e.errors = statistics.NewRegressionErrors()
+    for _, sample := range testSet {
+        e.errors.AddPrediction(sample[len(sample) - 1], e.regressor.Predict(sample))
+    }
+    return e.errors, nil
+}
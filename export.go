@@ -0,0 +1,24 @@
+package main
+
+import "errors"
+
+// Converts a model between formats. Converting to a format without metadata drops it.
+func runExport(args []string) error {
+    flagSet := newFlagSet("export", "")
+    modelFilePath := flagSet.String("model", "", "file to read the model from")
+    format := flagSet.String("format", "", "format of the model read: "+MODEL_FORMAT_USAGE)
+    outputFilePath := flagSet.String("output", "", "file to write the model to")
+    outputFormat := flagSet.String("output-format", "", "format of the model written: "+MODEL_FORMAT_USAGE)
+    if err := parseArguments(flagSet, args, 0); err != nil {
+        return err
+    }
+    if *modelFilePath == "" || *outputFilePath == "" {
+        return errors.New("the -model and -output flags are needed")
+    }
+
+    model, err := loadModel(*modelFilePath, *format)
+    if err != nil {
+        return err
+    }
+    return saveModel(*outputFilePath, *outputFormat, model)
+}
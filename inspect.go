@@ -0,0 +1,71 @@
+package main
+
+import (
+    "errors"
+    "fmt"
+    "sort"
+)
+
+// Dumps the metadata and the stumps of a model.
+func runInspect(args []string) error {
+    flagSet := newFlagSet("inspect", "")
+    modelFilePath := flagSet.String("model", "", "file to read the model from")
+    format := flagSet.String("format", "", "model format: "+MODEL_FORMAT_USAGE)
+    if err := parseArguments(flagSet, args, 0); err != nil {
+        return err
+    }
+    if *modelFilePath == "" {
+        return errors.New("the -model flag is needed")
+    }
+
+    model, err := loadModel(*modelFilePath, *format)
+    if err != nil {
+        return err
+    }
+    adaBoost := model.AdaBoost
+    fmt.Printf("Mode: %s\n", adaBoost.GetMode())
+    fmt.Printf("Features: %d\n", model.NumberOfFeatures)
+    if len(model.FeatureNames) > 0 {
+        fmt.Printf("Feature names: %v\n", model.FeatureNames)
+    }
+    labels := []int{}
+    for label := range model.Labels {
+        labels = append(labels, label)
+    }
+    sort.Ints(labels)
+    for _, label := range labels {
+        fmt.Printf("Label %d: %s\n", label, model.Labels[label])
+    }
+    fmt.Printf("Options: %+v\n", adaBoost.GetOptions())
+    if len(model.ClassDistribution.Classes) > 0 {
+        fmt.Printf("Class distribution: %d positive, %d negative\n", model.ClassDistribution.Positive, model.ClassDistribution.Negative)
+    }
+    if len(model.TrainingErrors) > 0 {
+        fmt.Printf("Training error: %v\n", model.TrainingErrors)
+    }
+    if len(model.ValidationMetrics) > 0 {
+        fmt.Printf("Validation %s: %v\n", adaBoost.GetOptions().EarlyStoppingMetric, model.ValidationMetrics)
+    }
+    if model.Evaluation != nil {
+        fmt.Printf("Evaluation:\n%s\n", model.Evaluation.String())
+    }
+    if model.Pipeline != nil {
+        fmt.Printf("Pipeline: %d inputs\n", model.Pipeline.NumberOfInputs)
+        for _, columnEncoder := range model.Pipeline.Encoders {
+            fmt.Printf("Input %d: %s %s\n", columnEncoder.Input, columnEncoder.Name, columnEncoder.Encoder.Kind())
+        }
+    }
+    fmt.Printf("Stumps: %d\n", len(adaBoost.WeakClassifiers))
+    for t, weakClassifier := range adaBoost.WeakClassifiers {
+        if len(model.FeatureNames) == 0 {
+            fmt.Printf("%d: %s %s\n", t, weakClassifier.Kind(), weakClassifier.String())
+            continue
+        }
+        names := []string{}
+        for _, featureNumber := range weakClassifier.GetFeatureNumbers() {
+            names = append(names, model.FeatureName(featureNumber))
+        }
+        fmt.Printf("%d: %s %v %s\n", t, weakClassifier.Kind(), names, weakClassifier.String())
+    }
+    return nil
+}
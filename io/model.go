@@ -0,0 +1,259 @@
+package io
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/static/model"
+    "github.com/dalmirdasilva/AdaBoostGo/classifier"
+    "github.com/dalmirdasilva/AdaBoostGo/preprocessing"
+    "github.com/dalmirdasilva/AdaBoostGo/statistics"
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+    "github.com/golang/protobuf/proto"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "strconv"
+    "errors"
+    "sort"
+    "fmt"
+)
+
+// Version of the model format written by the exporter. The importer reads the versions from 1 up to it. Version 2
+// added the preprocessing pipeline, and version 3 moved the checksum from the JSON encoding to the proto one.
+const MODEL_FORMAT_VERSION = 3
+
+var (
+    ErrIncompatibleVersion = errors.New("incompatible model format version")
+    ErrChecksumMismatch = errors.New("model checksum mismatch")
+)
+
+// A trained AdaBoost with the metadata telling where it came from.
+type Model struct {
+    AdaBoost          classifier.AdaBoost
+    NumberOfFeatures  uint
+    FeatureNames      []string
+    Labels            map[int]string
+    ClassDistribution statistics.ClassDistribution
+    TrainingErrors    []float64
+
+    // Metric over the validation set after each round, nil when not stopping early.
+    ValidationMetrics []float64
+
+    // Evaluation over the test set, nil when there was none.
+    Evaluation        *statistics.ContingencyTable
+
+    // Encoding of the samples' category columns into the features, nil when there was none.
+    Pipeline          *preprocessing.Pipeline
+}
+
+// Creates the model of a trained AdaBoost, naming its labels after their values.
+func NewModel(adaBoost classifier.AdaBoost, numberOfFeatures uint) Model {
+    return Model{
+        AdaBoost: adaBoost,
+        NumberOfFeatures: numberOfFeatures,
+        Labels: map[int]string{-1: "-1", 1: "1"},
+        TrainingErrors: adaBoost.GetTrainingErrors(),
+        ValidationMetrics: adaBoost.GetValidationMetrics(),
+    }
+}
+
+// Creates the model of an AdaBoost trained on a dataset, keeping the names of its features.
+func NewModelForDataset(adaBoost classifier.AdaBoost, dataset *utils.Dataset) Model {
+    model := NewModel(adaBoost, uint(dataset.NumberOfFeatures()))
+    model.FeatureNames = dataset.FeatureNames
+    return model
+}
+
+// Gets the name of a feature, or its number when the model has no names.
+func (m *Model) FeatureName(featureNumber uint) string {
+    if int(featureNumber) < len(m.FeatureNames) {
+        return m.FeatureNames[featureNumber]
+    }
+    return strconv.Itoa(int(featureNumber))
+}
+
+// Computes the checksum of a model: the hex encoded SHA-256 of its proto encoding without the checksum. The messages
+// have no maps, so the encoding is deterministic, and unlike JSON it holds any float, infinities and NaN included.
+func computeChecksum(modelProto *dom_distiller.ModelProto) (string, error) {
+    unsigned := *modelProto
+    unsigned.Checksum = nil
+    buf, err := proto.Marshal(&unsigned)
+    if err != nil {
+        return "", err
+    }
+    sum := sha256.Sum256(buf)
+    return hex.EncodeToString(sum[:]), nil
+}
+
+// Computes the checksum of the models written before version 3: the hex encoded SHA-256 of their JSON encoding
+// without the checksum.
+func computeJSONChecksum(modelProto *dom_distiller.ModelProto) (string, error) {
+    unsigned := *modelProto
+    unsigned.Checksum = nil
+    buf, err := json.Marshal(&unsigned)
+    if err != nil {
+        return "", err
+    }
+    sum := sha256.Sum256(buf)
+    return hex.EncodeToString(sum[:]), nil
+}
+
+func populateOptionsProto(options classifier.Options) *dom_distiller.OptionsProto {
+    optionsProto := dom_distiller.OptionsProto{}
+    optionsProto.NumberOfClassifiers = new(uint32)
+    *optionsProto.NumberOfClassifiers = uint32(options.NumberOfClassifiers)
+    optionsProto.LearningRate = new(float64)
+    *optionsProto.LearningRate = options.LearningRate
+    optionsProto.UseRandomWeakClassifiers = new(bool)
+    *optionsProto.UseRandomWeakClassifiers = options.UseRandomWeakClassifiers
+    optionsProto.NumberOfRandomClassifiers = new(int32)
+    *optionsProto.NumberOfRandomClassifiers = int32(options.NumberOfRandomClassifiers)
+    optionsProto.IncorporateCostSensitiveLearning = new(bool)
+    *optionsProto.IncorporateCostSensitiveLearning = options.IncorporateCostSensitiveLearning
+    optionsProto.UseThresholdClassification = new(bool)
+    *optionsProto.UseThresholdClassification = options.UseThresholdClassification
+    optionsProto.OverSamplingTrainingSet = new(bool)
+    *optionsProto.OverSamplingTrainingSet = options.OverSamplingTrainingSet
+    optionsProto.TestPercent = new(float64)
+    *optionsProto.TestPercent = options.TestPercent
+    optionsProto.NumberOfWorkers = new(int32)
+    *optionsProto.NumberOfWorkers = int32(options.NumberOfWorkers)
+    for _, featureNumber := range options.CategoricalFeatures {
+        optionsProto.CategoricalFeature = append(optionsProto.CategoricalFeature, uint32(featureNumber))
+    }
+    optionsProto.ValidationPercent = new(float64)
+    *optionsProto.ValidationPercent = options.ValidationPercent
+    optionsProto.EarlyStoppingRounds = new(uint32)
+    *optionsProto.EarlyStoppingRounds = uint32(options.EarlyStoppingRounds)
+    optionsProto.EarlyStoppingMetric = new(string)
+    *optionsProto.EarlyStoppingMetric = options.EarlyStoppingMetric.String()
+    return &optionsProto
+}
+
+// Options missing from the proto keep their default values.
+func readOptionsProto(optionsProto *dom_distiller.OptionsProto) classifier.Options {
+    options := classifier.DefaultOptions()
+    if optionsProto == nil {
+        return options
+    }
+    if optionsProto.NumberOfClassifiers != nil {
+        options.NumberOfClassifiers = uint(optionsProto.GetNumberOfClassifiers())
+    }
+    if optionsProto.LearningRate != nil {
+        options.LearningRate = optionsProto.GetLearningRate()
+    }
+    if optionsProto.UseRandomWeakClassifiers != nil {
+        options.UseRandomWeakClassifiers = optionsProto.GetUseRandomWeakClassifiers()
+    }
+    if optionsProto.NumberOfRandomClassifiers != nil {
+        options.NumberOfRandomClassifiers = int(optionsProto.GetNumberOfRandomClassifiers())
+    }
+    if optionsProto.IncorporateCostSensitiveLearning != nil {
+        options.IncorporateCostSensitiveLearning = optionsProto.GetIncorporateCostSensitiveLearning()
+    }
+    if optionsProto.UseThresholdClassification != nil {
+        options.UseThresholdClassification = optionsProto.GetUseThresholdClassification()
+    }
+    if optionsProto.OverSamplingTrainingSet != nil {
+        options.OverSamplingTrainingSet = optionsProto.GetOverSamplingTrainingSet()
+    }
+    if optionsProto.TestPercent != nil {
+        options.TestPercent = optionsProto.GetTestPercent()
+    }
+    if optionsProto.NumberOfWorkers != nil {
+        options.NumberOfWorkers = int(optionsProto.GetNumberOfWorkers())
+    }
+    for _, featureNumber := range optionsProto.GetCategoricalFeature() {
+        options.CategoricalFeatures = append(options.CategoricalFeatures, uint(featureNumber))
+    }
+    if optionsProto.ValidationPercent != nil {
+        options.ValidationPercent = optionsProto.GetValidationPercent()
+    }
+    if optionsProto.EarlyStoppingRounds != nil {
+        options.EarlyStoppingRounds = uint(optionsProto.GetEarlyStoppingRounds())
+    }
+    if metric, ok := classifier.ParseStoppingMetric(optionsProto.GetEarlyStoppingMetric()); ok {
+        options.EarlyStoppingMetric = metric
+    }
+    return options
+}
+
+func populatePipelineProto(pipeline *preprocessing.Pipeline) *dom_distiller.PipelineProto {
+    pipelineProto := dom_distiller.PipelineProto{}
+    pipelineProto.NumInputs = new(uint32)
+    *pipelineProto.NumInputs = uint32(pipeline.NumberOfInputs)
+    for _, columnEncoder := range pipeline.Encoders {
+        encoderProto := dom_distiller.EncoderProto{}
+        encoderProto.Kind = new(string)
+        *encoderProto.Kind = columnEncoder.Encoder.Kind()
+        encoderProto.Input = new(uint32)
+        *encoderProto.Input = uint32(columnEncoder.Input)
+        encoderProto.Name = new(string)
+        *encoderProto.Name = columnEncoder.Name
+        switch encoder := columnEncoder.Encoder.(type) {
+        case *preprocessing.OneHotEncoder:
+            encoderProto.Category = encoder.Categories
+        case *preprocessing.OrdinalEncoder:
+            encoderProto.Category = encoder.Categories
+        case *preprocessing.TargetEncoder:
+            encoderProto.Category = encoder.Categories
+            encoderProto.Value = encoder.Values
+            encoderProto.Prior = new(float64)
+            *encoderProto.Prior = encoder.Prior
+            encoderProto.Smoothing = new(float64)
+            *encoderProto.Smoothing = encoder.Smoothing
+            encoderProto.NumFolds = new(uint32)
+            *encoderProto.NumFolds = uint32(encoder.NumberOfFolds)
+        }
+        pipelineProto.Encoder = append(pipelineProto.Encoder, &encoderProto)
+    }
+    return &pipelineProto
+}
+
+// Reads a pipeline, checking it encodes its inputs into numberOfFeatures features.
+func readPipelineProto(pipelineProto *dom_distiller.PipelineProto, numberOfFeatures uint) (*preprocessing.Pipeline, error) {
+    if pipelineProto.NumInputs == nil {
+        return nil, fmt.Errorf("%w: num_inputs is required", ErrInvalidModel)
+    }
+    pipeline := &preprocessing.Pipeline{NumberOfInputs: int(pipelineProto.GetNumInputs())}
+    numberOfEncodedFeatures := pipeline.NumberOfInputs - len(pipelineProto.GetEncoder())
+    if numberOfEncodedFeatures < 0 {
+        return nil, fmt.Errorf("%w: %d encoders for %d inputs", ErrInvalidModel, len(pipelineProto.GetEncoder()), pipeline.NumberOfInputs)
+    }
+    previous := -1
+    for _, encoderProto := range pipelineProto.GetEncoder() {
+        input := int(encoderProto.GetInput())
+        if input <= previous || input >= pipeline.NumberOfInputs {
+            return nil, fmt.Errorf("%w: encoder input %d is out of order or out of [0, %d)", ErrInvalidModel, input, pipeline.NumberOfInputs)
+        }
+        previous = input
+        categories := encoderProto.GetCategory()
+        if !sort.StringsAreSorted(categories) {
+            return nil, fmt.Errorf("%w: the categories of encoder %s are not sorted", ErrInvalidModel, encoderProto.GetName())
+        }
+        var encoder preprocessing.Encoder
+        switch encoderProto.GetKind() {
+        case preprocessing.ONE_HOT_ENCODER:
+            encoder = &preprocessing.OneHotEncoder{Categories: categories}
+        case preprocessing.ORDINAL_ENCODER:
+            encoder = &preprocessing.OrdinalEncoder{Categories: categories}
+        case preprocessing.TARGET_ENCODER:
+            if len(encoderProto.GetValue()) != len(categories) {
+                return nil, fmt.Errorf("%w: %d values for %d categories in encoder %s", ErrInvalidModel, len(encoderProto.GetValue()), len(categories), encoderProto.GetName())
+            }
+            encoder = &preprocessing.TargetEncoder{
+                Categories: categories,
+                Values: encoderProto.GetValue(),
+                Prior: encoderProto.GetPrior(),
+                Smoothing: encoderProto.GetSmoothing(),
+                NumberOfFolds: int(encoderProto.GetNumFolds()),
+            }
+        default:
+            return nil, fmt.Errorf("%w: unknown encoder %s", ErrInvalidModel, encoderProto.GetKind())
+        }
+        pipeline.Encoders = append(pipeline.Encoders, preprocessing.ColumnEncoder{Input: input, Name: encoderProto.GetName(), Encoder: encoder})
+        numberOfEncodedFeatures += len(encoder.FeatureNames(encoderProto.GetName()))
+    }
+    if uint(numberOfEncodedFeatures) != numberOfFeatures {
+        return nil, fmt.Errorf("%w: the pipeline encodes %d features but the model has %d", ErrInvalidModel, numberOfEncodedFeatures, numberOfFeatures)
+    }
+    return pipeline, nil
+}
@@ -3,12 +3,22 @@ package io
 import (
     "github.com/dalmirdasilva/AdaBoostGo/static/model"
     "github.com/dalmirdasilva/AdaBoostGo/classifier"
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
     "github.com/golang/protobuf/proto"
     "encoding/json"
+    "errors"
+    "fmt"
     "io/ioutil"
-    "log"
+    "sort"
 )
 
+// The proto format only knows about stumps and confidence-rated stumps.
+var ErrUnsupportedWeakClassifier = errors.New("weak classifier is not supported by the proto format")
+
+// The bare AdaBoostProto, as read by Chromium, only knows threshold stumps, which categorical stumps would be taken for.
+// They are only saved in the model envelope.
+var ErrCategoricalStump = errors.New("categorical stumps are only supported by the model envelope")
+
 type ModelExporter struct {
 }
 
@@ -16,51 +26,381 @@ func NewModelExporter() ModelExporter {
     return ModelExporter{}
 }
 
-func (e *ModelExporter) populateProto(fileName string, classifier classifier.AdaBoost, numberOfFeatures uint) *dom_distiller.AdaBoostProto {
+func (e *ModelExporter) populateProto(adaBoost classifier.AdaBoost, numberOfFeatures uint) (*dom_distiller.AdaBoostProto, error) {
     adaBoostProto := dom_distiller.AdaBoostProto{}
     adaBoostProto.NumFeatures = new(int32)
     *adaBoostProto.NumFeatures = int32(numberOfFeatures)
     adaBoostProto.NumStumps = new(int32)
-    *adaBoostProto.NumStumps = int32(len(classifier.WeakClassifiers))
-    for _, weakClassifier := range classifier.WeakClassifiers {
-        stumpProto := dom_distiller.StumpProto{}
+    *adaBoostProto.NumStumps = int32(len(adaBoost.WeakClassifiers))
+    adaBoostProto.Mode = new(string)
+    *adaBoostProto.Mode = adaBoost.GetMode().String()
+    for _, weakClassifier := range adaBoost.WeakClassifiers {
+        stumpProto, err := e.populateStumpProto(weakClassifier)
+        if err != nil {
+            return nil, err
+        }
+        adaBoostProto.Stump = append(adaBoostProto.Stump, stumpProto)
+    }
+    return &adaBoostProto, nil
+}
+
+// The proto format only knows about stumps.
+func (e *ModelExporter) populateStumpProto(weakClassifier classifier.WeakClassifier) (*dom_distiller.StumpProto, error) {
+    stumpProto := dom_distiller.StumpProto{}
+    stumpProto.Weight = new(float64)
+    *stumpProto.Weight = weakClassifier.GetAlpha()
+    switch stump := weakClassifier.(type) {
+    case *classifier.Stump:
         stumpProto.FeatureNumber = new(int32)
-        *stumpProto.FeatureNumber = int32(weakClassifier.GetFeatureNumber())
+        *stumpProto.FeatureNumber = int32(stump.GetFeatureNumber())
         stumpProto.Split = new(float64)
-        *stumpProto.Split = weakClassifier.GetSplit()
-        stumpProto.Weight = new(float64)
-        *stumpProto.Weight = weakClassifier.GetAlpha()
-        adaBoostProto.Stump = append(adaBoostProto.Stump, &stumpProto)
+        *stumpProto.Split = stump.GetSplit()
+        stumpProto.Polarity = new(int32)
+        *stumpProto.Polarity = int32(stump.GetPolarity())
+        stumpProto.MissingAbove = new(bool)
+        *stumpProto.MissingAbove = stump.GetMissingAbove()
+        stumpProto.Category = stump.GetCategories()
+    case *classifier.ConfidenceStump:
+        stumpProto.FeatureNumber = new(int32)
+        *stumpProto.FeatureNumber = int32(stump.GetFeatureNumber())
+        stumpProto.Split = new(float64)
+        *stumpProto.Split = stump.GetSplit()
+        stumpProto.LeftValue = new(float64)
+        *stumpProto.LeftValue = stump.GetLeftValue()
+        stumpProto.RightValue = new(float64)
+        *stumpProto.RightValue = stump.GetRightValue()
+        stumpProto.MissingAbove = new(bool)
+        *stumpProto.MissingAbove = stump.GetMissingAbove()
+        stumpProto.Category = stump.GetCategories()
+    default:
+        return nil, fmt.Errorf("%w: %s", ErrUnsupportedWeakClassifier, weakClassifier.Kind())
+    }
+    return &stumpProto, nil
+}
+
+// Checks the stumps of an AdaBoost can be saved as a bare AdaBoostProto.
+func (e *ModelExporter) checkThresholdStumps(adaBoost classifier.AdaBoost) error {
+    for t, weakClassifier := range adaBoost.WeakClassifiers {
+        var categories []float64
+        switch stump := weakClassifier.(type) {
+        case *classifier.Stump:
+            categories = stump.GetCategories()
+        case *classifier.ConfidenceStump:
+            categories = stump.GetCategories()
+        }
+        if categories != nil {
+            return fmt.Errorf("%w: stump %d splits its feature into categories", ErrCategoricalStump, t)
+        }
     }
-    return &adaBoostProto
+    return nil
 }
 
-func (e *ModelExporter) ExportToProto(fileName string, classifier classifier.AdaBoost, numberOfFeatures uint) {
-    adaBoostProto := e.populateProto(fileName, classifier, numberOfFeatures)
+func (e *ModelExporter) ExportToProto(fileName string, classifier classifier.AdaBoost, numberOfFeatures uint) error {
+    if err := e.checkThresholdStumps(classifier); err != nil {
+        return err
+    }
+    adaBoostProto, err := e.populateProto(classifier, numberOfFeatures)
+    if err != nil {
+        return err
+    }
     buf, err := proto.Marshal(adaBoostProto)
     if err != nil {
-        log.Fatal(err)
+        return err
+    }
+    return e.writeFile(fileName, buf)
+}
+
+// Builds the envelope of the model, signed with its checksum.
+func (e *ModelExporter) populateModelProto(model Model) (*dom_distiller.ModelProto, error) {
+    adaBoostProto, err := e.populateProto(model.AdaBoost, model.NumberOfFeatures)
+    if err != nil {
+        return nil, err
+    }
+    modelProto := dom_distiller.ModelProto{}
+    modelProto.FormatVersion = new(int32)
+    *modelProto.FormatVersion = MODEL_FORMAT_VERSION
+    modelProto.AdaBoost = adaBoostProto
+    modelProto.FeatureName = model.FeatureNames
+    modelProto.Options = populateOptionsProto(model.AdaBoost.GetOptions())
+    modelProto.TrainingError = model.TrainingErrors
+    modelProto.ValidationMetric = model.ValidationMetrics
+
+    // Labels and classes are sorted, so the same model always gets the same checksum.
+    values := []int{}
+    for value := range model.Labels {
+        values = append(values, value)
+    }
+    sort.Ints(values)
+    for _, value := range values {
+        labelProto := dom_distiller.LabelProto{}
+        labelProto.Value = new(int32)
+        *labelProto.Value = int32(value)
+        labelProto.Name = new(string)
+        *labelProto.Name = model.Labels[value]
+        modelProto.Label = append(modelProto.Label, &labelProto)
+    }
+    labels := []int{}
+    for label := range model.ClassDistribution.Classes {
+        labels = append(labels, label)
+    }
+    sort.Ints(labels)
+    for _, label := range labels {
+        classCountProto := dom_distiller.ClassCountProto{}
+        classCountProto.Label = new(int32)
+        *classCountProto.Label = int32(label)
+        classCountProto.Count = new(uint32)
+        *classCountProto.Count = uint32(model.ClassDistribution.Classes[label])
+        modelProto.ClassCount = append(modelProto.ClassCount, &classCountProto)
+    }
+
+    if model.Evaluation != nil {
+        evaluationProto := dom_distiller.EvaluationProto{}
+        evaluationProto.TruePositive = new(uint32)
+        *evaluationProto.TruePositive = uint32(model.Evaluation.TruePositive())
+        evaluationProto.FalsePositive = new(uint32)
+        *evaluationProto.FalsePositive = uint32(model.Evaluation.FalsePositive())
+        evaluationProto.TrueNegative = new(uint32)
+        *evaluationProto.TrueNegative = uint32(model.Evaluation.TrueNegative())
+        evaluationProto.FalseNegative = new(uint32)
+        *evaluationProto.FalseNegative = uint32(model.Evaluation.FalseNegative())
+        modelProto.Evaluation = &evaluationProto
+    }
+
+    if model.Pipeline != nil {
+        modelProto.Pipeline = populatePipelineProto(model.Pipeline)
+    }
+
+    checksum, err := computeChecksum(&modelProto)
+    if err != nil {
+        return nil, err
+    }
+    modelProto.Checksum = &checksum
+    return &modelProto, nil
+}
+
+// Exports the model with its metadata in the versioned proto envelope.
+func (e *ModelExporter) ExportModelToProto(fileName string, model Model) error {
+    modelProto, err := e.populateModelProto(model)
+    if err != nil {
+        return err
+    }
+    buf, err := proto.Marshal(modelProto)
+    if err != nil {
+        return err
+    }
+    return e.writeFile(fileName, buf)
+}
+
+// Exports the model with its metadata in the versioned envelope, encoded as JSON.
+func (e *ModelExporter) ExportModelToJSON(fileName string, model Model) error {
+    modelProto, err := e.populateModelProto(model)
+    if err != nil {
+        return err
+    }
+    buf, err := json.Marshal(modelProto)
+    if err != nil {
+        return err
     }
-    ioutil.WriteFile(fileName, buf, 0600)
+    return e.writeFile(fileName, buf)
 }
 
-func (e *ModelExporter) ExportToJSON(fileName string, classifier classifier.AdaBoost, numberOfFeatures uint) {
+func (e *ModelExporter) ExportToJSON(fileName string, classifier classifier.AdaBoost, numberOfFeatures uint) error {
+    if err := e.checkThresholdStumps(classifier); err != nil {
+        return err
+    }
     var model = make(map[string]interface{})
     model["num_features"] = numberOfFeatures
     model["num_stumps"] = len(classifier.WeakClassifiers)
+    model["mode"] = classifier.GetMode().String()
     stumps := []map[string]interface{}{}
     for _, weakClassifier := range classifier.WeakClassifiers {
-        var stump = make(map[string]interface{})
-        stump["feature_number"] = weakClassifier.GetFeatureNumber()
-        stump["split"] = weakClassifier.GetSplit()
-        stump["weight"] = weakClassifier.GetAlpha()
+        stump := weakClassifier.ToMap()
+        stump["kind"] = weakClassifier.Kind()
         stumps = append(stumps, stump)
     }
     model["stump"] = stumps
+    return e.writeJSON(fileName, model)
+}
+
+// Builds the proto of a multiclass model, recording its class list and its mode.
+func (e *ModelExporter) populateMultiClassProto(multiClass classifier.MultiClassAdaBoost, numberOfFeatures uint) *dom_distiller.MultiClassAdaBoostProto {
+    multiClassProto := dom_distiller.MultiClassAdaBoostProto{}
+    multiClassProto.NumFeatures = new(int32)
+    *multiClassProto.NumFeatures = int32(numberOfFeatures)
+    multiClassProto.NumStumps = new(int32)
+    *multiClassProto.NumStumps = int32(len(multiClass.WeakClassifiers))
+    multiClassProto.Mode = new(string)
+    *multiClassProto.Mode = multiClass.GetMode().String()
+    for _, class := range multiClass.Classes {
+        multiClassProto.Class = append(multiClassProto.Class, int32(class))
+    }
+    for _, stump := range multiClass.WeakClassifiers {
+        stumpProto := dom_distiller.MultiClassStumpProto{}
+        stumpProto.FeatureNumber = new(int32)
+        *stumpProto.FeatureNumber = int32(stump.GetFeatureNumber())
+        stumpProto.Split = new(float64)
+        *stumpProto.Split = stump.GetSplit()
+        stumpProto.Weight = new(float64)
+        *stumpProto.Weight = stump.GetAlpha()
+        stumpProto.LeftProbability = stump.GetLeftProbabilities()
+        stumpProto.RightProbability = stump.GetRightProbabilities()
+        stumpProto.MissingAbove = new(bool)
+        *stumpProto.MissingAbove = stump.GetMissingAbove()
+        multiClassProto.Stump = append(multiClassProto.Stump, &stumpProto)
+    }
+    return &multiClassProto
+}
+
+// Exports a multiclass model to the proto format.
+func (e *ModelExporter) ExportMultiClassToProto(fileName string, classifier classifier.MultiClassAdaBoost, numberOfFeatures uint) error {
+    buf, err := proto.Marshal(e.populateMultiClassProto(classifier, numberOfFeatures))
+    if err != nil {
+        return err
+    }
+    return e.writeFile(fileName, buf)
+}
+
+// Exports a multiclass model to JSON, its fields being named after the proto ones.
+func (e *ModelExporter) ExportMultiClassToJSON(fileName string, classifier classifier.MultiClassAdaBoost, numberOfFeatures uint) error {
+    buf, err := json.Marshal(e.populateMultiClassProto(classifier, numberOfFeatures))
+    if err != nil {
+        return err
+    }
+    return e.writeFile(fileName, buf)
+}
+
+// Builds the proto of a multi-label AdaBoost.MH model. Each stump holds a vote per label.
+func (e *ModelExporter) populateMultiLabelProto(multiLabel classifier.AdaBoostMH, numberOfFeatures uint) *dom_distiller.MultiLabelAdaBoostProto {
+    multiLabelProto := dom_distiller.MultiLabelAdaBoostProto{}
+    multiLabelProto.NumFeatures = new(int32)
+    *multiLabelProto.NumFeatures = int32(numberOfFeatures)
+    multiLabelProto.NumStumps = new(int32)
+    *multiLabelProto.NumStumps = int32(len(multiLabel.WeakClassifiers))
+    multiLabelProto.NumLabels = new(int32)
+    *multiLabelProto.NumLabels = int32(multiLabel.GetNumberOfLabels())
+    for _, stump := range multiLabel.WeakClassifiers {
+        stumpProto := dom_distiller.MultiLabelStumpProto{}
+        stumpProto.FeatureNumber = new(int32)
+        *stumpProto.FeatureNumber = int32(stump.GetFeatureNumber())
+        stumpProto.Split = new(float64)
+        *stumpProto.Split = stump.GetSplit()
+        stumpProto.Weight = new(float64)
+        *stumpProto.Weight = stump.GetAlpha()
+        for _, vote := range stump.GetVotes() {
+            stumpProto.Vote = append(stumpProto.Vote, int32(vote))
+        }
+        stumpProto.MissingAbove = new(bool)
+        *stumpProto.MissingAbove = stump.GetMissingAbove()
+        multiLabelProto.Stump = append(multiLabelProto.Stump, &stumpProto)
+    }
+    return &multiLabelProto
+}
+
+// Exports a multi-label AdaBoost.MH model to the proto format.
+func (e *ModelExporter) ExportMultiLabelToProto(fileName string, classifier classifier.AdaBoostMH, numberOfFeatures uint) error {
+    buf, err := proto.Marshal(e.populateMultiLabelProto(classifier, numberOfFeatures))
+    if err != nil {
+        return err
+    }
+    return e.writeFile(fileName, buf)
+}
+
+// Exports a multi-label AdaBoost.MH model to JSON, its fields being named after the proto ones.
+func (e *ModelExporter) ExportMultiLabelToJSON(fileName string, classifier classifier.AdaBoostMH, numberOfFeatures uint) error {
+    buf, err := json.Marshal(e.populateMultiLabelProto(classifier, numberOfFeatures))
+    if err != nil {
+        return err
+    }
+    return e.writeFile(fileName, buf)
+}
+
+// Builds the proto of an AdaBoost.R2 regressor, with the weight of each regressor in the weighted median.
+func (e *ModelExporter) populateRegressorProto(regressor classifier.AdaBoostR2, numberOfFeatures uint) (*dom_distiller.RegressorAdaBoostProto, error) {
+    regressorProto := dom_distiller.RegressorAdaBoostProto{}
+    regressorProto.NumFeatures = new(int32)
+    *regressorProto.NumFeatures = int32(numberOfFeatures)
+    regressorProto.NumRegressors = new(int32)
+    *regressorProto.NumRegressors = int32(len(regressor.WeakRegressors))
+    regressorProto.Loss = new(string)
+    *regressorProto.Loss = regressor.GetLoss().String()
+    for t, weakRegressor := range regressor.WeakRegressors {
+        weakRegressorProto := dom_distiller.RegressorProto{}
+        weakRegressorProto.MedianWeight = new(float64)
+        *weakRegressorProto.MedianWeight = regressor.RegressorWeights[t]
+        if tree, ok := weakRegressor.(*classifier.RegressionTree); ok {
+            weakRegressorProto.Tree = &dom_distiller.RegressionTreeProto{}
+            weakRegressorProto.Tree.Weight = new(float64)
+            *weakRegressorProto.Tree.Weight = tree.GetAlpha()
+            weakRegressorProto.Tree.Root = e.populateRegressionNodeProto(tree.GetRoot())
+        } else {
+            stumpProto, err := e.populateStumpProto(weakRegressor)
+            if err != nil {
+                return nil, err
+            }
+            weakRegressorProto.Stump = stumpProto
+        }
+        regressorProto.Regressor = append(regressorProto.Regressor, &weakRegressorProto)
+    }
+    return &regressorProto, nil
+}
+
+// Leaves only hold their value.
+func (e *ModelExporter) populateRegressionNodeProto(node *classifier.RegressionNode) *dom_distiller.RegressionNodeProto {
+    nodeProto := dom_distiller.RegressionNodeProto{}
+    if node.IsLeaf() {
+        nodeProto.Value = new(float64)
+        *nodeProto.Value = node.Value
+        return &nodeProto
+    }
+    nodeProto.FeatureNumber = new(int32)
+    *nodeProto.FeatureNumber = int32(node.FeatureNumber)
+    nodeProto.Split = new(float64)
+    *nodeProto.Split = node.Split
+    nodeProto.Category = node.Categories
+    nodeProto.MissingAbove = new(bool)
+    *nodeProto.MissingAbove = node.MissingAbove
+    nodeProto.Left = e.populateRegressionNodeProto(node.Left)
+    nodeProto.Right = e.populateRegressionNodeProto(node.Right)
+    return &nodeProto
+}
+
+// Exports an AdaBoost.R2 regressor to the proto format.
+func (e *ModelExporter) ExportRegressorToProto(fileName string, regressor classifier.AdaBoostR2, numberOfFeatures uint) error {
+    regressorProto, err := e.populateRegressorProto(regressor, numberOfFeatures)
+    if err != nil {
+        return err
+    }
+    buf, err := proto.Marshal(regressorProto)
+    if err != nil {
+        return err
+    }
+    return e.writeFile(fileName, buf)
+}
+
+// Exports an AdaBoost.R2 regressor to JSON, its fields being named after the proto ones.
+func (e *ModelExporter) ExportRegressorToJSON(fileName string, regressor classifier.AdaBoostR2, numberOfFeatures uint) error {
+    regressorProto, err := e.populateRegressorProto(regressor, numberOfFeatures)
+    if err != nil {
+        return err
+    }
+    buf, err := json.Marshal(regressorProto)
+    if err != nil {
+        return err
+    }
+    return e.writeFile(fileName, buf)
+}
+
+func (e *ModelExporter) writeJSON(fileName string, model map[string]interface{}) error {
     buf, err := json.Marshal(model)
     if err != nil {
-        log.Fatal(err)
+        return err
     }
-    ioutil.WriteFile(fileName, buf, 0600)
+    return e.writeFile(fileName, buf)
 }
 
+func (e *ModelExporter) writeFile(fileName string, buf []byte) error {
+    if err := ioutil.WriteFile(fileName, buf, 0600); err != nil {
+        return utils.NewIOError("write", fileName, err)
+    }
+    return nil
+}
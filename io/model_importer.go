@@ -0,0 +1,507 @@
+package io
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/static/model"
+    "github.com/dalmirdasilva/AdaBoostGo/classifier"
+    "github.com/dalmirdasilva/AdaBoostGo/statistics"
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+    "github.com/golang/protobuf/proto"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io/ioutil"
+    "math"
+    "sort"
+)
+
+// Returned, wrapped with the reason, when a model file is not consistent.
+var ErrInvalidModel = errors.New("invalid model")
+
+// Loads the models written by ModelExporter back into classifiers.
+type ModelImporter struct {
+}
+
+func NewModelImporter() ModelImporter {
+    return ModelImporter{}
+}
+
+// Imports an AdaBoost from the proto format, returning it with its number of features.
+func (i *ModelImporter) ImportFromProto(fileName string) (classifier.AdaBoost, uint, error) {
+    buf, err := i.readFile(fileName)
+    if err != nil {
+        return classifier.AdaBoost{}, 0, err
+    }
+    adaBoostProto := dom_distiller.AdaBoostProto{}
+    if err := proto.Unmarshal(buf, &adaBoostProto); err != nil {
+        return classifier.AdaBoost{}, 0, fmt.Errorf("%w: %v", ErrInvalidModel, err)
+    }
+    return i.readProto(&adaBoostProto, classifier.DefaultOptions())
+}
+
+// Imports an AdaBoost from JSON, returning it with its number of features.
+//
+// The JSON fields are named after the proto ones, so it is decoded into the proto message. Each stump may also hold
+// its kind, which must be one the proto format knows about.
+func (i *ModelImporter) ImportFromJSON(fileName string) (classifier.AdaBoost, uint, error) {
+    buf, err := i.readFile(fileName)
+    if err != nil {
+        return classifier.AdaBoost{}, 0, err
+    }
+    adaBoostProto := dom_distiller.AdaBoostProto{}
+    if err := json.Unmarshal(buf, &adaBoostProto); err != nil {
+        return classifier.AdaBoost{}, 0, fmt.Errorf("%w: %v", ErrInvalidModel, err)
+    }
+    var kinds struct {
+        Stump []struct {
+            Kind string `json:"kind"`
+        } `json:"stump"`
+    }
+    if err := json.Unmarshal(buf, &kinds); err != nil {
+        return classifier.AdaBoost{}, 0, fmt.Errorf("%w: %v", ErrInvalidModel, err)
+    }
+    for t, stump := range kinds.Stump {
+        if stump.Kind != "" && stump.Kind != classifier.STUMP_KIND && stump.Kind != classifier.CONFIDENCE_STUMP_KIND {
+            return classifier.AdaBoost{}, 0, fmt.Errorf("%w: stump %d is a %s", ErrUnsupportedWeakClassifier, t, stump.Kind)
+        }
+    }
+    return i.readProto(&adaBoostProto, classifier.DefaultOptions())
+}
+
+// Imports a multiclass AdaBoost from the proto format, returning it with its number of features.
+func (i *ModelImporter) ImportMultiClassFromProto(fileName string) (classifier.MultiClassAdaBoost, uint, error) {
+    buf, err := i.readFile(fileName)
+    if err != nil {
+        return classifier.MultiClassAdaBoost{}, 0, err
+    }
+    multiClassProto := dom_distiller.MultiClassAdaBoostProto{}
+    if err := proto.Unmarshal(buf, &multiClassProto); err != nil {
+        return classifier.MultiClassAdaBoost{}, 0, fmt.Errorf("%w: %v", ErrInvalidModel, err)
+    }
+    return i.readMultiClassProto(&multiClassProto)
+}
+
+// Imports a multiclass AdaBoost from JSON, returning it with its number of features.
+func (i *ModelImporter) ImportMultiClassFromJSON(fileName string) (classifier.MultiClassAdaBoost, uint, error) {
+    buf, err := i.readFile(fileName)
+    if err != nil {
+        return classifier.MultiClassAdaBoost{}, 0, err
+    }
+    multiClassProto := dom_distiller.MultiClassAdaBoostProto{}
+    if err := json.Unmarshal(buf, &multiClassProto); err != nil {
+        return classifier.MultiClassAdaBoost{}, 0, fmt.Errorf("%w: %v", ErrInvalidModel, err)
+    }
+    return i.readMultiClassProto(&multiClassProto)
+}
+
+// Validates the proto and builds the multiclass AdaBoost it describes, with its class list and its mode.
+func (i *ModelImporter) readMultiClassProto(multiClassProto *dom_distiller.MultiClassAdaBoostProto) (classifier.MultiClassAdaBoost, uint, error) {
+    if multiClassProto.NumStumps == nil || multiClassProto.NumFeatures == nil {
+        return classifier.MultiClassAdaBoost{}, 0, fmt.Errorf("%w: num_stumps and num_features are required", ErrInvalidModel)
+    }
+    numberOfFeatures := multiClassProto.GetNumFeatures()
+    if numberOfFeatures < 1 {
+        return classifier.MultiClassAdaBoost{}, 0, fmt.Errorf("%w: num_features is %d", ErrInvalidModel, numberOfFeatures)
+    }
+    if int(multiClassProto.GetNumStumps()) != len(multiClassProto.GetStump()) {
+        return classifier.MultiClassAdaBoost{}, 0, fmt.Errorf("%w: num_stumps is %d but there are %d stumps", ErrInvalidModel, multiClassProto.GetNumStumps(), len(multiClassProto.GetStump()))
+    }
+    mode, ok := classifier.ParseMultiClassMode(multiClassProto.GetMode())
+    if !ok {
+        return classifier.MultiClassAdaBoost{}, 0, fmt.Errorf("%w: unknown mode %s", ErrInvalidModel, multiClassProto.GetMode())
+    }
+    classes := []int{}
+    for k, class := range multiClassProto.GetClass() {
+        if k > 0 && int(class) <= classes[k - 1] {
+            return classifier.MultiClassAdaBoost{}, 0, fmt.Errorf("%w: the classes are not sorted", ErrInvalidModel)
+        }
+        classes = append(classes, int(class))
+    }
+    if len(classes) < 2 {
+        return classifier.MultiClassAdaBoost{}, 0, fmt.Errorf("%w: %d classes", ErrInvalidModel, len(classes))
+    }
+
+    multiClass := classifier.NewMultiClassAdaBoost(mode, classifier.DefaultOptions())
+    multiClass.Classes = classes
+    for t, stumpProto := range multiClassProto.GetStump() {
+        if stumpProto.FeatureNumber == nil || stumpProto.Split == nil || stumpProto.Weight == nil {
+            return classifier.MultiClassAdaBoost{}, 0, fmt.Errorf("stump %d: %w: feature_number, split and weight are required", t, ErrInvalidModel)
+        }
+        featureNumber := stumpProto.GetFeatureNumber()
+        if featureNumber < 0 || featureNumber >= numberOfFeatures {
+            return classifier.MultiClassAdaBoost{}, 0, fmt.Errorf("stump %d: %w: feature_number %d is out of [0, %d)", t, ErrInvalidModel, featureNumber, numberOfFeatures)
+        }
+        if len(stumpProto.GetLeftProbability()) != len(classes) || len(stumpProto.GetRightProbability()) != len(classes) {
+            return classifier.MultiClassAdaBoost{}, 0, fmt.Errorf("stump %d: %w: %d left and %d right probabilities for %d classes", t, ErrInvalidModel, len(stumpProto.GetLeftProbability()), len(stumpProto.GetRightProbability()), len(classes))
+        }
+        stump := classifier.NewMultiClassStump(uint(featureNumber), stumpProto.GetSplit(), stumpProto.GetLeftProbability(), stumpProto.GetRightProbability())
+        stump.SetMissingAbove(stumpProto.GetMissingAbove())
+        stump.SetAlpha(stumpProto.GetWeight())
+        multiClass.WeakClassifiers = append(multiClass.WeakClassifiers, stump)
+    }
+    return multiClass, uint(numberOfFeatures), nil
+}
+
+// Imports a multi-label AdaBoost.MH from the proto format, returning it with its number of features.
+func (i *ModelImporter) ImportMultiLabelFromProto(fileName string) (classifier.AdaBoostMH, uint, error) {
+    buf, err := i.readFile(fileName)
+    if err != nil {
+        return classifier.AdaBoostMH{}, 0, err
+    }
+    multiLabelProto := dom_distiller.MultiLabelAdaBoostProto{}
+    if err := proto.Unmarshal(buf, &multiLabelProto); err != nil {
+        return classifier.AdaBoostMH{}, 0, fmt.Errorf("%w: %v", ErrInvalidModel, err)
+    }
+    return i.readMultiLabelProto(&multiLabelProto)
+}
+
+// Imports a multi-label AdaBoost.MH from JSON, returning it with its number of features.
+func (i *ModelImporter) ImportMultiLabelFromJSON(fileName string) (classifier.AdaBoostMH, uint, error) {
+    buf, err := i.readFile(fileName)
+    if err != nil {
+        return classifier.AdaBoostMH{}, 0, err
+    }
+    multiLabelProto := dom_distiller.MultiLabelAdaBoostProto{}
+    if err := json.Unmarshal(buf, &multiLabelProto); err != nil {
+        return classifier.AdaBoostMH{}, 0, fmt.Errorf("%w: %v", ErrInvalidModel, err)
+    }
+    return i.readMultiLabelProto(&multiLabelProto)
+}
+
+// Validates the proto and builds the AdaBoost.MH it describes.
+func (i *ModelImporter) readMultiLabelProto(multiLabelProto *dom_distiller.MultiLabelAdaBoostProto) (classifier.AdaBoostMH, uint, error) {
+    if multiLabelProto.NumStumps == nil || multiLabelProto.NumFeatures == nil || multiLabelProto.NumLabels == nil {
+        return classifier.AdaBoostMH{}, 0, fmt.Errorf("%w: num_stumps, num_features and num_labels are required", ErrInvalidModel)
+    }
+    numberOfFeatures := multiLabelProto.GetNumFeatures()
+    if numberOfFeatures < 1 {
+        return classifier.AdaBoostMH{}, 0, fmt.Errorf("%w: num_features is %d", ErrInvalidModel, numberOfFeatures)
+    }
+    numberOfLabels := multiLabelProto.GetNumLabels()
+    if numberOfLabels < 1 {
+        return classifier.AdaBoostMH{}, 0, fmt.Errorf("%w: num_labels is %d", ErrInvalidModel, numberOfLabels)
+    }
+    if int(multiLabelProto.GetNumStumps()) != len(multiLabelProto.GetStump()) {
+        return classifier.AdaBoostMH{}, 0, fmt.Errorf("%w: num_stumps is %d but there are %d stumps", ErrInvalidModel, multiLabelProto.GetNumStumps(), len(multiLabelProto.GetStump()))
+    }
+
+    multiLabel := classifier.NewAdaBoostMH(uint(numberOfLabels), classifier.DefaultOptions())
+    for t, stumpProto := range multiLabelProto.GetStump() {
+        if stumpProto.FeatureNumber == nil || stumpProto.Split == nil || stumpProto.Weight == nil {
+            return classifier.AdaBoostMH{}, 0, fmt.Errorf("stump %d: %w: feature_number, split and weight are required", t, ErrInvalidModel)
+        }
+        featureNumber := stumpProto.GetFeatureNumber()
+        if featureNumber < 0 || featureNumber >= numberOfFeatures {
+            return classifier.AdaBoostMH{}, 0, fmt.Errorf("stump %d: %w: feature_number %d is out of [0, %d)", t, ErrInvalidModel, featureNumber, numberOfFeatures)
+        }
+        if len(stumpProto.GetVote()) != int(numberOfLabels) {
+            return classifier.AdaBoostMH{}, 0, fmt.Errorf("stump %d: %w: %d votes for %d labels", t, ErrInvalidModel, len(stumpProto.GetVote()), numberOfLabels)
+        }
+        votes := make([]int, numberOfLabels)
+        for l, vote := range stumpProto.GetVote() {
+            if vote != 1 && vote != -1 {
+                return classifier.AdaBoostMH{}, 0, fmt.Errorf("stump %d: %w: vote %d is neither -1 nor 1", t, ErrInvalidModel, vote)
+            }
+            votes[l] = int(vote)
+        }
+        stump := classifier.NewMultiLabelStump(uint(featureNumber), stumpProto.GetSplit(), votes)
+        stump.SetMissingAbove(stumpProto.GetMissingAbove())
+        stump.SetAlpha(stumpProto.GetWeight())
+        multiLabel.WeakClassifiers = append(multiLabel.WeakClassifiers, stump)
+    }
+    return multiLabel, uint(numberOfFeatures), nil
+}
+
+// Imports an AdaBoost.R2 regressor from the proto format, returning it with its number of features.
+func (i *ModelImporter) ImportRegressorFromProto(fileName string) (classifier.AdaBoostR2, uint, error) {
+    buf, err := i.readFile(fileName)
+    if err != nil {
+        return classifier.AdaBoostR2{}, 0, err
+    }
+    regressorProto := dom_distiller.RegressorAdaBoostProto{}
+    if err := proto.Unmarshal(buf, &regressorProto); err != nil {
+        return classifier.AdaBoostR2{}, 0, fmt.Errorf("%w: %v", ErrInvalidModel, err)
+    }
+    return i.readRegressorProto(&regressorProto)
+}
+
+// Imports an AdaBoost.R2 regressor from JSON, returning it with its number of features.
+func (i *ModelImporter) ImportRegressorFromJSON(fileName string) (classifier.AdaBoostR2, uint, error) {
+    buf, err := i.readFile(fileName)
+    if err != nil {
+        return classifier.AdaBoostR2{}, 0, err
+    }
+    regressorProto := dom_distiller.RegressorAdaBoostProto{}
+    if err := json.Unmarshal(buf, &regressorProto); err != nil {
+        return classifier.AdaBoostR2{}, 0, fmt.Errorf("%w: %v", ErrInvalidModel, err)
+    }
+    return i.readRegressorProto(&regressorProto)
+}
+
+// Validates the proto and builds the AdaBoost.R2 it describes.
+func (i *ModelImporter) readRegressorProto(regressorProto *dom_distiller.RegressorAdaBoostProto) (classifier.AdaBoostR2, uint, error) {
+    if regressorProto.NumRegressors == nil || regressorProto.NumFeatures == nil {
+        return classifier.AdaBoostR2{}, 0, fmt.Errorf("%w: num_regressors and num_features are required", ErrInvalidModel)
+    }
+    numberOfFeatures := regressorProto.GetNumFeatures()
+    if numberOfFeatures < 1 {
+        return classifier.AdaBoostR2{}, 0, fmt.Errorf("%w: num_features is %d", ErrInvalidModel, numberOfFeatures)
+    }
+    if int(regressorProto.GetNumRegressors()) != len(regressorProto.GetRegressor()) {
+        return classifier.AdaBoostR2{}, 0, fmt.Errorf("%w: num_regressors is %d but there are %d regressors", ErrInvalidModel, regressorProto.GetNumRegressors(), len(regressorProto.GetRegressor()))
+    }
+    loss, ok := classifier.ParseRegressionLoss(regressorProto.GetLoss())
+    if !ok {
+        return classifier.AdaBoostR2{}, 0, fmt.Errorf("%w: unknown loss %q", ErrInvalidModel, regressorProto.GetLoss())
+    }
+
+    regressor := classifier.NewAdaBoostR2(loss, classifier.DefaultOptions())
+    for t, weakRegressorProto := range regressorProto.GetRegressor() {
+        if weakRegressorProto.MedianWeight == nil {
+            return classifier.AdaBoostR2{}, 0, fmt.Errorf("regressor %d: %w: median_weight is required", t, ErrInvalidModel)
+        }
+        medianWeight := weakRegressorProto.GetMedianWeight()
+        if math.IsNaN(medianWeight) || math.IsInf(medianWeight, 0) || medianWeight < 0 {
+            return classifier.AdaBoostR2{}, 0, fmt.Errorf("regressor %d: %w: median_weight %v is not a finite non-negative number", t, ErrInvalidModel, medianWeight)
+        }
+        var weakRegressor classifier.WeakClassifier
+        switch {
+        case weakRegressorProto.Tree != nil && weakRegressorProto.Stump == nil:
+            treeProto := weakRegressorProto.GetTree()
+            if treeProto.Weight == nil || treeProto.Root == nil {
+                return classifier.AdaBoostR2{}, 0, fmt.Errorf("regressor %d: %w: weight and root are required", t, ErrInvalidModel)
+            }
+            root, err := i.readRegressionNodeProto(treeProto.GetRoot(), numberOfFeatures)
+            if err != nil {
+                return classifier.AdaBoostR2{}, 0, fmt.Errorf("regressor %d: %w", t, err)
+            }
+            tree := classifier.NewRegressionTree(root)
+            tree.SetAlpha(treeProto.GetWeight())
+            weakRegressor = tree
+        case weakRegressorProto.Stump != nil && weakRegressorProto.Tree == nil:
+            stump, err := i.readStumpProto(weakRegressorProto.GetStump(), numberOfFeatures)
+            if err != nil {
+                return classifier.AdaBoostR2{}, 0, fmt.Errorf("regressor %d: %w", t, err)
+            }
+            weakRegressor = stump
+        default:
+            return classifier.AdaBoostR2{}, 0, fmt.Errorf("regressor %d: %w: exactly one of tree and stump is required", t, ErrInvalidModel)
+        }
+        regressor.WeakRegressors = append(regressor.WeakRegressors, weakRegressor)
+        regressor.RegressorWeights = append(regressor.RegressorWeights, medianWeight)
+    }
+    return regressor, uint(numberOfFeatures), nil
+}
+
+// Nodes without children are leaves and need a value, the others need both children and their split.
+func (i *ModelImporter) readRegressionNodeProto(nodeProto *dom_distiller.RegressionNodeProto, numberOfFeatures int32) (*classifier.RegressionNode, error) {
+    if nodeProto.Left == nil && nodeProto.Right == nil {
+        if nodeProto.Value == nil {
+            return nil, fmt.Errorf("%w: leaves need a value", ErrInvalidModel)
+        }
+        return &classifier.RegressionNode{Value: nodeProto.GetValue()}, nil
+    }
+    if nodeProto.Left == nil || nodeProto.Right == nil {
+        return nil, fmt.Errorf("%w: nodes need both children or none", ErrInvalidModel)
+    }
+    if nodeProto.FeatureNumber == nil || nodeProto.Split == nil {
+        return nil, fmt.Errorf("%w: feature_number and split are required", ErrInvalidModel)
+    }
+    featureNumber := nodeProto.GetFeatureNumber()
+    if featureNumber < 0 || featureNumber >= numberOfFeatures {
+        return nil, fmt.Errorf("%w: feature_number %d is out of [0, %d)", ErrInvalidModel, featureNumber, numberOfFeatures)
+    }
+    categories, err := readCategories(nodeProto.GetCategory())
+    if err != nil {
+        return nil, err
+    }
+    left, err := i.readRegressionNodeProto(nodeProto.GetLeft(), numberOfFeatures)
+    if err != nil {
+        return nil, err
+    }
+    right, err := i.readRegressionNodeProto(nodeProto.GetRight(), numberOfFeatures)
+    if err != nil {
+        return nil, err
+    }
+    return &classifier.RegressionNode{
+        FeatureNumber: uint(featureNumber),
+        Split: nodeProto.GetSplit(),
+        Categories: categories,
+        MissingAbove: nodeProto.GetMissingAbove(),
+        Left: left,
+        Right: right,
+    }, nil
+}
+
+// Imports a model with its metadata from the versioned proto envelope.
+func (i *ModelImporter) ImportModelFromProto(fileName string) (Model, error) {
+    buf, err := i.readFile(fileName)
+    if err != nil {
+        return Model{}, err
+    }
+    modelProto := dom_distiller.ModelProto{}
+    if err := proto.Unmarshal(buf, &modelProto); err != nil {
+        return Model{}, fmt.Errorf("%w: %v", ErrInvalidModel, err)
+    }
+    return i.readModelProto(&modelProto)
+}
+
+// Imports a model with its metadata from the versioned envelope encoded as JSON.
+func (i *ModelImporter) ImportModelFromJSON(fileName string) (Model, error) {
+    buf, err := i.readFile(fileName)
+    if err != nil {
+        return Model{}, err
+    }
+    modelProto := dom_distiller.ModelProto{}
+    if err := json.Unmarshal(buf, &modelProto); err != nil {
+        return Model{}, fmt.Errorf("%w: %v", ErrInvalidModel, err)
+    }
+    return i.readModelProto(&modelProto)
+}
+
+// Checks the version and the checksum of the envelope before reading the model.
+func (i *ModelImporter) readModelProto(modelProto *dom_distiller.ModelProto) (Model, error) {
+    version := modelProto.GetFormatVersion()
+    if version < 1 || version > MODEL_FORMAT_VERSION {
+        return Model{}, fmt.Errorf("%w: %d, expected from 1 to %d", ErrIncompatibleVersion, version, MODEL_FORMAT_VERSION)
+    }
+    if modelProto.Checksum == nil {
+        return Model{}, fmt.Errorf("%w: missing checksum", ErrInvalidModel)
+    }
+    checksum, err := computeChecksum(modelProto)
+    if version < 3 {
+        checksum, err = computeJSONChecksum(modelProto)
+    }
+    if err != nil {
+        return Model{}, err
+    }
+    if checksum != modelProto.GetChecksum() {
+        return Model{}, fmt.Errorf("%w: got %s, computed %s", ErrChecksumMismatch, modelProto.GetChecksum(), checksum)
+    }
+    if modelProto.AdaBoost == nil {
+        return Model{}, fmt.Errorf("%w: ada_boost is required", ErrInvalidModel)
+    }
+
+    adaBoost, numberOfFeatures, err := i.readProto(modelProto.GetAdaBoost(), readOptionsProto(modelProto.GetOptions()))
+    if err != nil {
+        return Model{}, err
+    }
+    if len(modelProto.GetFeatureName()) > 0 && len(modelProto.GetFeatureName()) != int(numberOfFeatures) {
+        return Model{}, fmt.Errorf("%w: %d feature names for %d features", ErrInvalidModel, len(modelProto.GetFeatureName()), numberOfFeatures)
+    }
+    model := Model{
+        AdaBoost: adaBoost,
+        NumberOfFeatures: numberOfFeatures,
+        FeatureNames: modelProto.GetFeatureName(),
+        Labels: make(map[int]string),
+        ClassDistribution: statistics.ClassDistribution{Classes: make(map[int]uint)},
+        TrainingErrors: modelProto.GetTrainingError(),
+        ValidationMetrics: modelProto.GetValidationMetric(),
+    }
+    for _, labelProto := range modelProto.GetLabel() {
+        model.Labels[int(labelProto.GetValue())] = labelProto.GetName()
+    }
+    for _, classCountProto := range modelProto.GetClassCount() {
+        label := int(classCountProto.GetLabel())
+        count := uint(classCountProto.GetCount())
+        model.ClassDistribution.Classes[label] = count
+        if label == -1 {
+            model.ClassDistribution.Negative += count
+        } else {
+            model.ClassDistribution.Positive += count
+        }
+    }
+    if evaluationProto := modelProto.GetEvaluation(); evaluationProto != nil {
+        evaluation := statistics.NewContingencyTableFromCounts(uint(evaluationProto.GetTruePositive()), uint(evaluationProto.GetFalsePositive()), uint(evaluationProto.GetTrueNegative()), uint(evaluationProto.GetFalseNegative()))
+        model.Evaluation = &evaluation
+    }
+    if pipelineProto := modelProto.GetPipeline(); pipelineProto != nil {
+        if model.Pipeline, err = readPipelineProto(pipelineProto, numberOfFeatures); err != nil {
+            return Model{}, err
+        }
+    }
+    return model, nil
+}
+
+// Validates the proto and builds the AdaBoost it describes, trained with the given options.
+func (i *ModelImporter) readProto(adaBoostProto *dom_distiller.AdaBoostProto, options classifier.Options) (classifier.AdaBoost, uint, error) {
+    if adaBoostProto.NumStumps == nil || adaBoostProto.NumFeatures == nil {
+        return classifier.AdaBoost{}, 0, fmt.Errorf("%w: num_stumps and num_features are required", ErrInvalidModel)
+    }
+    numberOfFeatures := adaBoostProto.GetNumFeatures()
+    if numberOfFeatures < 1 {
+        return classifier.AdaBoost{}, 0, fmt.Errorf("%w: num_features is %d", ErrInvalidModel, numberOfFeatures)
+    }
+    if int(adaBoostProto.GetNumStumps()) != len(adaBoostProto.GetStump()) {
+        return classifier.AdaBoost{}, 0, fmt.Errorf("%w: num_stumps is %d but there are %d stumps", ErrInvalidModel, adaBoostProto.GetNumStumps(), len(adaBoostProto.GetStump()))
+    }
+    mode, ok := classifier.ParseBoostingMode(adaBoostProto.GetMode())
+    if !ok {
+        return classifier.AdaBoost{}, 0, fmt.Errorf("%w: unknown mode %s", ErrInvalidModel, adaBoostProto.GetMode())
+    }
+
+    options.Mode = mode
+    adaBoost := classifier.NewAdaBoost(options)
+    for t, stumpProto := range adaBoostProto.GetStump() {
+        weakClassifier, err := i.readStumpProto(stumpProto, numberOfFeatures)
+        if err != nil {
+            return classifier.AdaBoost{}, 0, fmt.Errorf("stump %d: %w", t, err)
+        }
+        adaBoost.WeakClassifiers = append(adaBoost.WeakClassifiers, weakClassifier)
+    }
+    return adaBoost, uint(numberOfFeatures), nil
+}
+
+// Stumps holding leaf values are confidence-rated, the others split by polarity. Both send the samples missing the
+// feature to the recorded side of the split, and are categorical when they hold categories.
+func (i *ModelImporter) readStumpProto(stumpProto *dom_distiller.StumpProto, numberOfFeatures int32) (classifier.WeakClassifier, error) {
+    if stumpProto.FeatureNumber == nil || stumpProto.Split == nil || stumpProto.Weight == nil {
+        return nil, fmt.Errorf("%w: feature_number, split and weight are required", ErrInvalidModel)
+    }
+    featureNumber := stumpProto.GetFeatureNumber()
+    if featureNumber < 0 || featureNumber >= numberOfFeatures {
+        return nil, fmt.Errorf("%w: feature_number %d is out of [0, %d)", ErrInvalidModel, featureNumber, numberOfFeatures)
+    }
+    categories, err := readCategories(stumpProto.GetCategory())
+    if err != nil {
+        return nil, err
+    }
+    if stumpProto.LeftValue != nil || stumpProto.RightValue != nil {
+        stump := classifier.NewConfidenceStump(uint(featureNumber), stumpProto.GetSplit(), stumpProto.GetLeftValue(), stumpProto.GetRightValue())
+        stump.SetCategories(categories)
+        stump.SetMissingAbove(stumpProto.GetMissingAbove())
+        stump.SetAlpha(stumpProto.GetWeight())
+        return stump, nil
+    }
+    polarity := stumpProto.GetPolarity()
+    if polarity != 1 && polarity != -1 {
+        return nil, fmt.Errorf("%w: polarity %d is neither -1 nor 1", ErrInvalidModel, polarity)
+    }
+    stump := classifier.NewStumpWithPolarity(uint(featureNumber), stumpProto.GetSplit(), int(polarity))
+    stump.SetCategories(categories)
+    stump.SetMissingAbove(stumpProto.GetMissingAbove())
+    stump.SetAlpha(stumpProto.GetWeight())
+    return stump, nil
+}
+
+// Gets the categories of a stump sorted, nil when it has none.
+func readCategories(categories []float64) ([]float64, error) {
+    if len(categories) == 0 {
+        return nil, nil
+    }
+    sorted := append([]float64{}, categories...)
+    sort.Float64s(sorted)
+    for _, category := range sorted {
+        if math.IsNaN(category) {
+            return nil, fmt.Errorf("%w: NaN category", ErrInvalidModel)
+        }
+    }
+    return sorted, nil
+}
+
+func (i *ModelImporter) readFile(fileName string) ([]byte, error) {
+    buf, err := ioutil.ReadFile(fileName)
+    if err != nil {
+        return nil, utils.NewIOError("read", fileName, err)
+    }
+    return buf, nil
+}
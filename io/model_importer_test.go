@@ -0,0 +1,461 @@
+package io
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/classifier"
+    "github.com/dalmirdasilva/AdaBoostGo/statistics"
+    "errors"
+    "io/ioutil"
+    "math"
+    "math/rand"
+    "path/filepath"
+    "reflect"
+    "strings"
+    "testing"
+)
+
+// Generates samples with three features, the first one deciding the class, -1 or 1, but for some noise. The values
+// are small integers, so they also make categories, and some are missing.
+func randomSamples(random *rand.Rand, numberOfSamples int) [][]float64 {
+    samples := make([][]float64, numberOfSamples)
+    for i := range samples {
+        sample := []float64{float64(random.Intn(6)), float64(random.Intn(4)), float64(random.Intn(10)), -1}
+        if sample[0] > 2 != (random.Float64() < 0.1) {
+            sample[3] = 1
+        }
+        for j := 0; j < 3; j++ {
+            if random.Float64() < 0.1 {
+                sample[j] = math.NaN()
+            }
+        }
+        samples[i] = sample
+    }
+    return samples
+}
+
+// Checks two AdaBoosts give the same score to every sample.
+func checkSameScores(t *testing.T, got, want classifier.AdaBoost, samples [][]float64) {
+    t.Helper()
+    if len(got.WeakClassifiers) != len(want.WeakClassifiers) {
+        t.Fatalf("imported %d weak classifiers, want %d", len(got.WeakClassifiers), len(want.WeakClassifiers))
+    }
+    for i, sample := range samples {
+        if got, want := got.Classify(sample), want.Classify(sample); got != want {
+            t.Fatalf("sample %d: imported score is %v, want %v", i, got, want)
+        }
+    }
+}
+
+func TestBareRoundTrip(t *testing.T) {
+    tests := []struct {
+        name   string
+        mode   classifier.BoostingMode
+        format string
+    }{
+        {"discrete proto", classifier.DISCRETE_ADABOOST, "proto"},
+        {"discrete JSON", classifier.DISCRETE_ADABOOST, "json"},
+        {"real proto", classifier.REAL_ADABOOST, "proto"},
+        {"real JSON", classifier.REAL_ADABOOST, "json"},
+        {"gentle proto", classifier.GENTLE_ADABOOST, "proto"},
+        {"logitboost JSON", classifier.LOGITBOOST, "json"},
+    }
+    for seed, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            samples := randomSamples(rand.New(rand.NewSource(int64(seed))), 100)
+            options := classifier.DefaultOptions()
+            options.Mode = test.mode
+            options.NumberOfClassifiers = 10
+            adaBoost := classifier.NewAdaBoost(options)
+            if err := adaBoost.Train(samples); err != nil {
+                t.Fatal(err)
+            }
+
+            exporter, importer := NewModelExporter(), NewModelImporter()
+            fileName := filepath.Join(t.TempDir(), "model." + test.format)
+            var imported classifier.AdaBoost
+            var numberOfFeatures uint
+            var err error
+            if test.format == "proto" {
+                if err := exporter.ExportToProto(fileName, adaBoost, 3); err != nil {
+                    t.Fatal(err)
+                }
+                imported, numberOfFeatures, err = importer.ImportFromProto(fileName)
+            } else {
+                if err := exporter.ExportToJSON(fileName, adaBoost, 3); err != nil {
+                    t.Fatal(err)
+                }
+                imported, numberOfFeatures, err = importer.ImportFromJSON(fileName)
+            }
+            if err != nil {
+                t.Fatal(err)
+            }
+            if numberOfFeatures != 3 || imported.GetMode() != test.mode {
+                t.Errorf("imported %d features and mode %s, want 3 and %s", numberOfFeatures, imported.GetMode(), test.mode)
+            }
+            checkSameScores(t, imported, adaBoost, samples)
+        })
+    }
+}
+
+func TestModelRoundTrip(t *testing.T) {
+    tests := []struct {
+        name                string
+        mode                classifier.BoostingMode
+        categoricalFeatures []uint
+        format              string
+    }{
+        {"discrete proto", classifier.DISCRETE_ADABOOST, nil, "proto"},
+        {"discrete JSON", classifier.DISCRETE_ADABOOST, nil, "json"},
+        {"categorical proto", classifier.DISCRETE_ADABOOST, []uint{0, 1}, "proto"},
+        {"categorical real JSON", classifier.REAL_ADABOOST, []uint{1}, "json"},
+        {"gentle proto", classifier.GENTLE_ADABOOST, nil, "proto"},
+        {"logitboost JSON", classifier.LOGITBOOST, nil, "json"},
+    }
+    for seed, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            random := rand.New(rand.NewSource(int64(seed)))
+            samples := randomSamples(random, 100)
+            options := classifier.DefaultOptions()
+            options.Mode = test.mode
+            options.NumberOfClassifiers = 10
+            options.CategoricalFeatures = test.categoricalFeatures
+            options.EarlyStoppingRounds = 5
+            adaBoost := classifier.NewAdaBoost(options)
+            if err := adaBoost.SetValidationSet(randomSamples(random, 30)); err != nil {
+                t.Fatal(err)
+            }
+            if err := adaBoost.Train(samples); err != nil {
+                t.Fatal(err)
+            }
+            model := NewModel(adaBoost, 3)
+            model.FeatureNames = []string{"a", "b", "c"}
+            model.Labels = map[int]string{-1: "ham", 1: "spam"}
+            evaluation := statistics.NewContingencyTableFromCounts(4, 3, 2, 1)
+            model.Evaluation = &evaluation
+
+            exporter, importer := NewModelExporter(), NewModelImporter()
+            fileName := filepath.Join(t.TempDir(), "model." + test.format)
+            var imported Model
+            var err error
+            if test.format == "proto" {
+                if err := exporter.ExportModelToProto(fileName, model); err != nil {
+                    t.Fatal(err)
+                }
+                imported, err = importer.ImportModelFromProto(fileName)
+            } else {
+                if err := exporter.ExportModelToJSON(fileName, model); err != nil {
+                    t.Fatal(err)
+                }
+                imported, err = importer.ImportModelFromJSON(fileName)
+            }
+            if err != nil {
+                t.Fatal(err)
+            }
+            checkSameScores(t, imported.AdaBoost, adaBoost, samples)
+            if imported.NumberOfFeatures != 3 || !reflect.DeepEqual(imported.FeatureNames, model.FeatureNames) || !reflect.DeepEqual(imported.Labels, model.Labels) {
+                t.Errorf("imported %d features named %v and labels %v", imported.NumberOfFeatures, imported.FeatureNames, imported.Labels)
+            }
+            if !reflect.DeepEqual(imported.TrainingErrors, model.TrainingErrors) || !reflect.DeepEqual(imported.ValidationMetrics, model.ValidationMetrics) {
+                t.Errorf("imported training errors %v and validation metrics %v, want %v and %v", imported.TrainingErrors, imported.ValidationMetrics, model.TrainingErrors, model.ValidationMetrics)
+            }
+            if !reflect.DeepEqual(imported.AdaBoost.GetOptions(), options) {
+                t.Errorf("imported options %+v, want %+v", imported.AdaBoost.GetOptions(), options)
+            }
+            if imported.Evaluation == nil || *imported.Evaluation != evaluation {
+                t.Errorf("imported evaluation %v, want %v", imported.Evaluation, evaluation)
+            }
+        })
+    }
+}
+
+func TestModelChecksumMismatch(t *testing.T) {
+    samples := randomSamples(rand.New(rand.NewSource(1)), 50)
+    options := classifier.DefaultOptions()
+    options.NumberOfClassifiers = 3
+    adaBoost := classifier.NewAdaBoost(options)
+    if err := adaBoost.Train(samples); err != nil {
+        t.Fatal(err)
+    }
+    model := NewModel(adaBoost, 3)
+    model.FeatureNames = []string{"a", "b", "c"}
+    exporter, importer := NewModelExporter(), NewModelImporter()
+    fileName := filepath.Join(t.TempDir(), "model.json")
+    if err := exporter.ExportModelToJSON(fileName, model); err != nil {
+        t.Fatal(err)
+    }
+    buf, err := ioutil.ReadFile(fileName)
+    if err != nil {
+        t.Fatal(err)
+    }
+    tampered := strings.Replace(string(buf), `"feature_name":["a"`, `"feature_name":["z"`, 1)
+    if tampered == string(buf) {
+        t.Fatalf("no feature names in %s", buf)
+    }
+    if err := ioutil.WriteFile(fileName, []byte(tampered), 0644); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := importer.ImportModelFromJSON(fileName); !errors.Is(err, ErrChecksumMismatch) {
+        t.Errorf("error is %v, want %v", err, ErrChecksumMismatch)
+    }
+}
+
+// Gives the samples of randomSamples one of three classes, 3, 7 or 9, decided by their first feature but for some
+// noise.
+func multiClassSamples(random *rand.Rand, numberOfSamples int) [][]float64 {
+    classes := []float64{3, 7, 9}
+    samples := randomSamples(random, numberOfSamples)
+    for _, sample := range samples {
+        sample[3] = classes[random.Intn(3)]
+        if !math.IsNaN(sample[0]) && random.Float64() > 0.1 {
+            sample[3] = classes[int(sample[0]) / 2]
+        }
+    }
+    return samples
+}
+
+func TestMultiClassRoundTrip(t *testing.T) {
+    tests := []struct {
+        name   string
+        mode   classifier.MultiClassMode
+        format string
+    }{
+        {"SAMME proto", classifier.SAMME, "proto"},
+        {"SAMME JSON", classifier.SAMME, "json"},
+        {"SAMME.R proto", classifier.SAMME_R, "proto"},
+        {"SAMME.R JSON", classifier.SAMME_R, "json"},
+    }
+    for seed, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            samples := multiClassSamples(rand.New(rand.NewSource(int64(seed))), 100)
+            options := classifier.DefaultOptions()
+            options.NumberOfClassifiers = 10
+            multiClass := classifier.NewMultiClassAdaBoost(test.mode, options)
+            if err := multiClass.Train(samples); err != nil {
+                t.Fatal(err)
+            }
+
+            exporter, importer := NewModelExporter(), NewModelImporter()
+            fileName := filepath.Join(t.TempDir(), "model." + test.format)
+            var imported classifier.MultiClassAdaBoost
+            var numberOfFeatures uint
+            var err error
+            if test.format == "proto" {
+                if err := exporter.ExportMultiClassToProto(fileName, multiClass, 3); err != nil {
+                    t.Fatal(err)
+                }
+                imported, numberOfFeatures, err = importer.ImportMultiClassFromProto(fileName)
+            } else {
+                if err := exporter.ExportMultiClassToJSON(fileName, multiClass, 3); err != nil {
+                    t.Fatal(err)
+                }
+                imported, numberOfFeatures, err = importer.ImportMultiClassFromJSON(fileName)
+            }
+            if err != nil {
+                t.Fatal(err)
+            }
+            if numberOfFeatures != 3 || imported.GetMode() != test.mode || !reflect.DeepEqual(imported.Classes, multiClass.Classes) {
+                t.Errorf("imported %d features, mode %s and classes %v, want 3, %s and %v", numberOfFeatures, imported.GetMode(), imported.Classes, test.mode, multiClass.Classes)
+            }
+            if len(imported.WeakClassifiers) != len(multiClass.WeakClassifiers) {
+                t.Fatalf("imported %d weak classifiers, want %d", len(imported.WeakClassifiers), len(multiClass.WeakClassifiers))
+            }
+            for i, sample := range samples {
+                if got, want := imported.Scores(sample), multiClass.Scores(sample); !reflect.DeepEqual(got, want) {
+                    t.Fatalf("sample %d: imported scores are %v, want %v", i, got, want)
+                }
+                if got, want := imported.Classify(sample), multiClass.Classify(sample); got != want {
+                    t.Fatalf("sample %d: imported class is %d, want %d", i, got, want)
+                }
+            }
+        })
+    }
+}
+
+// Gives the samples of randomSamples two labels, -1 or 1, the first decided by their first feature and the second by
+// their third one, but for some noise.
+func multiLabelSamples(random *rand.Rand, numberOfSamples int) [][]float64 {
+    samples := randomSamples(random, numberOfSamples)
+    for i, sample := range samples {
+        second := -1.0
+        if sample[2] > 4 != (random.Float64() < 0.1) {
+            second = 1
+        }
+        samples[i] = append(sample, second)
+    }
+    return samples
+}
+
+func TestMultiLabelRoundTrip(t *testing.T) {
+    tests := []struct {
+        name         string
+        learningRate float64
+        format       string
+    }{
+        {"proto", 1, "proto"},
+        {"JSON", 1, "json"},
+        {"shrunk proto", 0.3, "proto"},
+        {"shrunk JSON", 0.3, "json"},
+    }
+    for seed, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            samples := multiLabelSamples(rand.New(rand.NewSource(int64(seed))), 100)
+            options := classifier.DefaultOptions()
+            options.NumberOfClassifiers = 10
+            options.LearningRate = test.learningRate
+            multiLabel := classifier.NewAdaBoostMH(2, options)
+            if err := multiLabel.Train(samples); err != nil {
+                t.Fatal(err)
+            }
+
+            exporter, importer := NewModelExporter(), NewModelImporter()
+            fileName := filepath.Join(t.TempDir(), "model." + test.format)
+            var imported classifier.AdaBoostMH
+            var numberOfFeatures uint
+            var err error
+            if test.format == "proto" {
+                if err := exporter.ExportMultiLabelToProto(fileName, multiLabel, 3); err != nil {
+                    t.Fatal(err)
+                }
+                imported, numberOfFeatures, err = importer.ImportMultiLabelFromProto(fileName)
+            } else {
+                if err := exporter.ExportMultiLabelToJSON(fileName, multiLabel, 3); err != nil {
+                    t.Fatal(err)
+                }
+                imported, numberOfFeatures, err = importer.ImportMultiLabelFromJSON(fileName)
+            }
+            if err != nil {
+                t.Fatal(err)
+            }
+            if numberOfFeatures != 3 || imported.GetNumberOfLabels() != 2 || len(imported.WeakClassifiers) != len(multiLabel.WeakClassifiers) {
+                t.Fatalf("imported %d features, %d labels and %d weak classifiers, want 3, 2 and %d", numberOfFeatures, imported.GetNumberOfLabels(), len(imported.WeakClassifiers), len(multiLabel.WeakClassifiers))
+            }
+            for i, sample := range samples {
+                if got, want := imported.Scores(sample), multiLabel.Scores(sample); !reflect.DeepEqual(got, want) {
+                    t.Fatalf("sample %d: imported scores are %v, want %v", i, got, want)
+                }
+            }
+        })
+    }
+}
+
+func TestMultiLabelInvalidModel(t *testing.T) {
+    tests := []struct {
+        name  string
+        model string
+    }{
+        {"no labels", `{"num_stumps":0,"num_features":3,"num_labels":0}`},
+        {"missing stump", `{"num_stumps":1,"num_features":3,"num_labels":2}`},
+        {"feature out of range", `{"num_stumps":1,"num_features":3,"num_labels":2,"stump":[{"feature_number":3,"split":0,"weight":1,"vote":[1,-1]}]}`},
+        {"too few votes", `{"num_stumps":1,"num_features":3,"num_labels":2,"stump":[{"feature_number":0,"split":0,"weight":1,"vote":[1]}]}`},
+        {"vote of 0", `{"num_stumps":1,"num_features":3,"num_labels":2,"stump":[{"feature_number":0,"split":0,"weight":1,"vote":[1,0]}]}`},
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            fileName := filepath.Join(t.TempDir(), "model.json")
+            if err := ioutil.WriteFile(fileName, []byte(test.model), 0644); err != nil {
+                t.Fatal(err)
+            }
+            importer := NewModelImporter()
+            if _, _, err := importer.ImportMultiLabelFromJSON(fileName); !errors.Is(err, ErrInvalidModel) {
+                t.Errorf("error is %v, want %v", err, ErrInvalidModel)
+            }
+        })
+    }
+}
+
+// Gives the samples of randomSamples a continuous target, mostly decided by their first and second features.
+func regressionSamples(random *rand.Rand, numberOfSamples int) [][]float64 {
+    samples := randomSamples(random, numberOfSamples)
+    for _, sample := range samples {
+        sample[3] = random.NormFloat64()
+        if !math.IsNaN(sample[0]) && !math.IsNaN(sample[1]) {
+            sample[3] += 2 * sample[0] - sample[1] * sample[1]
+        }
+    }
+    return samples
+}
+
+func TestRegressorRoundTrip(t *testing.T) {
+    tests := []struct {
+        name                string
+        loss                classifier.RegressionLoss
+        stumps              bool
+        categoricalFeatures []uint
+        format              string
+    }{
+        {"trees proto", classifier.LINEAR_LOSS, false, nil, "proto"},
+        {"trees JSON", classifier.SQUARE_LOSS, false, nil, "json"},
+        {"categorical trees proto", classifier.EXPONENTIAL_LOSS, false, []uint{1}, "proto"},
+        {"stumps proto", classifier.SQUARE_LOSS, true, nil, "proto"},
+        {"categorical stumps JSON", classifier.LINEAR_LOSS, true, []uint{0, 1}, "json"},
+    }
+    for seed, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            samples := regressionSamples(rand.New(rand.NewSource(int64(seed))), 100)
+            options := classifier.DefaultOptions()
+            options.NumberOfClassifiers = 10
+            options.CategoricalFeatures = test.categoricalFeatures
+            regressor := classifier.NewAdaBoostR2(test.loss, options)
+            if test.stumps {
+                regressor = classifier.NewAdaBoostR2WithWeakLearner(classifier.NewRegressionStumpLearner(options), test.loss, options)
+            }
+            if err := regressor.Train(samples); err != nil {
+                t.Fatal(err)
+            }
+
+            exporter, importer := NewModelExporter(), NewModelImporter()
+            fileName := filepath.Join(t.TempDir(), "model." + test.format)
+            var imported classifier.AdaBoostR2
+            var numberOfFeatures uint
+            var err error
+            if test.format == "proto" {
+                if err := exporter.ExportRegressorToProto(fileName, regressor, 3); err != nil {
+                    t.Fatal(err)
+                }
+                imported, numberOfFeatures, err = importer.ImportRegressorFromProto(fileName)
+            } else {
+                if err := exporter.ExportRegressorToJSON(fileName, regressor, 3); err != nil {
+                    t.Fatal(err)
+                }
+                imported, numberOfFeatures, err = importer.ImportRegressorFromJSON(fileName)
+            }
+            if err != nil {
+                t.Fatal(err)
+            }
+            if numberOfFeatures != 3 || imported.GetLoss() != test.loss || !reflect.DeepEqual(imported.RegressorWeights, regressor.RegressorWeights) {
+                t.Fatalf("imported %d features, loss %s and weights %v, want 3, %s and %v", numberOfFeatures, imported.GetLoss(), imported.RegressorWeights, test.loss, regressor.RegressorWeights)
+            }
+            for i, sample := range samples {
+                if got, want := imported.Predict(sample), regressor.Predict(sample); got != want {
+                    t.Fatalf("sample %d: imported prediction is %v, want %v", i, got, want)
+                }
+            }
+        })
+    }
+}
+
+func TestRegressorInvalidModel(t *testing.T) {
+    tests := []struct {
+        name  string
+        model string
+    }{
+        {"unknown loss", `{"num_regressors":0,"num_features":3,"loss":"huber"}`},
+        {"missing regressor", `{"num_regressors":1,"num_features":3}`},
+        {"negative median weight", `{"num_regressors":1,"num_features":3,"regressor":[{"median_weight":-1,"tree":{"weight":1,"root":{"value":2}}}]}`},
+        {"neither tree nor stump", `{"num_regressors":1,"num_features":3,"regressor":[{"median_weight":1}]}`},
+        {"leaf without value", `{"num_regressors":1,"num_features":3,"regressor":[{"median_weight":1,"tree":{"weight":1,"root":{}}}]}`},
+        {"node with one child", `{"num_regressors":1,"num_features":3,"regressor":[{"median_weight":1,"tree":{"weight":1,"root":{"feature_number":0,"split":1,"left":{"value":2}}}}]}`},
+        {"feature out of range", `{"num_regressors":1,"num_features":3,"regressor":[{"median_weight":1,"tree":{"weight":1,"root":{"feature_number":3,"split":1,"left":{"value":2},"right":{"value":3}}}}]}`},
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            fileName := filepath.Join(t.TempDir(), "model.json")
+            if err := ioutil.WriteFile(fileName, []byte(test.model), 0644); err != nil {
+                t.Fatal(err)
+            }
+            importer := NewModelImporter()
+            if _, _, err := importer.ImportRegressorFromJSON(fileName); !errors.Is(err, ErrInvalidModel) {
+                t.Errorf("error is %v, want %v", err, ErrInvalidModel)
+            }
+        })
+    }
+}
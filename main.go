@@ -1,86 +1,78 @@
 package main
 
 import (
-    "github.com/dalmirdasilva/AdaBoostGo/classifier"
-    "github.com/dalmirdasilva/AdaBoostGo/evaluation"
-    "github.com/dalmirdasilva/AdaBoostGo/statistics"
-    "github.com/dalmirdasilva/AdaBoostGo/config"
-    "github.com/dalmirdasilva/AdaBoostGo/utils"
+    "path/filepath"
     "math/rand"
     "time"
+    "flag"
     "fmt"
     "log"
     "os"
 )
 
+// A subcommand of the command line, run with the arguments following its name.
+type command struct {
+    name        string
+    description string
+    run         func(args []string) error
+}
+
+var commands = []command{
+    {"train", "trains a model on a labelled CSV or LIBSVM file and writes it", runTrain},
+    {"evaluate", "scores a labelled CSV or LIBSVM file against a model", runEvaluate},
+    {"predict", "appends the scores and labels to the rows of an unlabelled CSV file", runPredict},
+    {"inspect", "dumps the metadata and the stumps of a model", runInspect},
+    {"export", "converts a model between formats", runExport},
+}
+
 func main() {
 
     rand.Seed(time.Now().UTC().UnixNano())
-    trainingDataFilePath := os.Args[1]
-
-    samples := utils.ReadSamples(trainingDataFilePath)
 
-    numberOfSamples := len(samples)
-    if numberOfSamples < 1 {
-        log.Fatal("At least one sample is needed.")
+    if len(os.Args) < 2 {
+        usage()
+        os.Exit(2)
     }
-
-    numberOfFeatures := len(samples[0]) - 1
-    if numberOfFeatures < 1 {
-        log.Fatal("At least feature is needed.")
+    for _, command := range commands {
+        if command.name == os.Args[1] {
+            if err := command.run(os.Args[2:]); err != nil {
+                log.Fatal(err)
+            }
+            return
+        }
     }
+    usage()
+    os.Exit(2)
+}
 
-    testSize := int(config.TEST_PERCENT * float64(len(samples)))
-    testSamples := samples[:testSize]
-
-    trainingSamples := samples[testSize:]
-    fmt.Println(len(trainingSamples))
-
-    adaBoost := classifier.NewAdaBoost(config.NUM_OF_WEAK_CLASSIFIERS)
-    adaBoost.Train(trainingSamples)
-
-    evaluator := evaluation.NewEvaluator(&adaBoost)
-    contingencyTable := evaluator.Evaluate(testSamples)
-
-    fmt.Println(evaluator.GetUsedFeatureNumbers(false))
-    fmt.Println(evaluator.GetFeatureOccurrences())
-    fmt.Println(contingencyTable.String())
+func usage() {
+    fmt.Fprintf(os.Stderr, "usage: %s <command> [flags] [arguments]\n\ncommands:\n", programName())
+    for _, command := range commands {
+        fmt.Fprintf(os.Stderr, "  %-10s %s\n", command.name, command.description)
+    }
+    fmt.Fprintf(os.Stderr, "\nRun '%s <command> -h' for the flags of a command.\n", programName())
+}
 
-    analyzer := statistics.NewFeaturesAnalyzer()
-    stats, distribution := analyzer.Analyze(trainingSamples)
-    fmt.Println(stats, distribution)
+func programName() string {
+    return filepath.Base(os.Args[0])
+}
 
-    //for _, sample := range currentRound {
-    //    sample = append(sample, adaBoost.Classify(sample))
-    //    fmt.Println(sample)
-    //}
-    //
-    ////
-    //for _, weakClassifier := range adaBoost.WeakClassifiers {
-    //    fmt.Println(weakClassifier.String())
-    //}
+// Creates the flag set of a command, whose usage shows the given arguments.
+func newFlagSet(name string, arguments string) *flag.FlagSet {
+    flagSet := flag.NewFlagSet(name, flag.ExitOnError)
+    flagSet.Usage = func() {
+        fmt.Fprintf(os.Stderr, "usage: %s %s [flags] %s\n\nflags:\n", programName(), name, arguments)
+        flagSet.PrintDefaults()
+    }
+    return flagSet
+}
 
-    //var variableRelations []statistics.VariableRelations
-    //for i := 0; i < numberOfFeatures; i++ {
-    //    for j := i + 1; j < numberOfFeatures; j++ {
-    //        variableRelations = append(variableRelations, analyzer.Correlation(uint(i), uint(j), trainingSamples, stats))
-    //    }
-    //}
-    //sort.Sort(statistics.SortableVariableRelations(variableRelations))
-    //for _, variableRelation := range variableRelations {
-    //    if variableRelation.Cov != 0 {
-    //        b, _ := json.Marshal(variableRelation)
-    //        fmt.Println(string(b) + ", ")
-    //    }
-    //}
-    //
-    //for i := 0; i < numberOfFeatures; i++ {
-    //    variableRelation := analyzer.Correlation(uint(i), uint(29), trainingSamples, stats)
-    //    b, _ := json.Marshal(variableRelation)
-    //    fmt.Println(string(b) + ", ")
-    //}
-    //
-    //me := io.NewModelExporter()
-    ////me.ExportToProto("/tmp/proto.bin", adaBoost, uint(numberOfFeatures))
-    //me.ExportToJSON("/tmp/proto.json", adaBoost, uint(numberOfFeatures))
-}
\ No newline at end of file
+// Parses the arguments of a command expecting the given number of positional arguments.
+func parseArguments(flagSet *flag.FlagSet, args []string, numberOfArguments int) error {
+    flagSet.Parse(args)
+    if flagSet.NArg() != numberOfArguments {
+        flagSet.Usage()
+        return fmt.Errorf("%s expects %d arguments, got %d", flagSet.Name(), numberOfArguments, flagSet.NArg())
+    }
+    return nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/io"
+    "path/filepath"
+    "fmt"
+)
+
+// Formats of the model files.
+const (
+
+    // Versioned envelope holding the model and its metadata.
+    PROTO_MODEL_FORMAT = "proto"
+    JSON_MODEL_FORMAT = "json"
+
+    // Bare AdaBoostProto, as read by Chromium, without metadata.
+    ADABOOST_PROTO_MODEL_FORMAT = "adaboost-proto"
+    ADABOOST_JSON_MODEL_FORMAT = "adaboost-json"
+)
+
+const MODEL_FORMAT_USAGE = "proto, json, adaboost-proto or adaboost-json. When empty, json for files ending with .json and proto otherwise"
+
+// Gets the format of a model file, inferring it from the file name when not given.
+func modelFormat(fileName string, format string) (string, error) {
+    switch format {
+    case "":
+        if filepath.Ext(fileName) == ".json" {
+            return JSON_MODEL_FORMAT, nil
+        }
+        return PROTO_MODEL_FORMAT, nil
+    case PROTO_MODEL_FORMAT, JSON_MODEL_FORMAT, ADABOOST_PROTO_MODEL_FORMAT, ADABOOST_JSON_MODEL_FORMAT:
+        return format, nil
+    }
+    return "", fmt.Errorf("unknown model format %s", format)
+}
+
+// Loads a model. Models without metadata only get the metadata NewModel gives them.
+func loadModel(fileName string, format string) (io.Model, error) {
+    format, err := modelFormat(fileName, format)
+    if err != nil {
+        return io.Model{}, err
+    }
+    importer := io.NewModelImporter()
+    switch format {
+    case JSON_MODEL_FORMAT:
+        return importer.ImportModelFromJSON(fileName)
+    case ADABOOST_PROTO_MODEL_FORMAT:
+        adaBoost, numberOfFeatures, err := importer.ImportFromProto(fileName)
+        return io.NewModel(adaBoost, numberOfFeatures), err
+    case ADABOOST_JSON_MODEL_FORMAT:
+        adaBoost, numberOfFeatures, err := importer.ImportFromJSON(fileName)
+        return io.NewModel(adaBoost, numberOfFeatures), err
+    }
+    return importer.ImportModelFromProto(fileName)
+}
+
+// Saves a model. The formats without metadata drop it, but cannot save a model with a preprocessing pipeline, which
+// would not classify the same without it.
+func saveModel(fileName string, format string, model io.Model) error {
+    format, err := modelFormat(fileName, format)
+    if err != nil {
+        return err
+    }
+    if model.Pipeline != nil && (format == ADABOOST_PROTO_MODEL_FORMAT || format == ADABOOST_JSON_MODEL_FORMAT) {
+        return fmt.Errorf("the %s format cannot hold the preprocessing pipeline of the model", format)
+    }
+    exporter := io.NewModelExporter()
+    switch format {
+    case JSON_MODEL_FORMAT:
+        return exporter.ExportModelToJSON(fileName, model)
+    case ADABOOST_PROTO_MODEL_FORMAT:
+        return exporter.ExportToProto(fileName, model.AdaBoost, model.NumberOfFeatures)
+    case ADABOOST_JSON_MODEL_FORMAT:
+        return exporter.ExportToJSON(fileName, model.AdaBoost, model.NumberOfFeatures)
+    }
+    return exporter.ExportModelToProto(fileName, model)
+}
+
+// Checks the samples hold the features of the model followed by their labels.
+func checkNumberOfFeatures(samples dataset, model io.Model) error {
+    if err := samples.validate(); err != nil {
+        return err
+    }
+    if numberOfFeatures := samples.numberOfFeatures(); numberOfFeatures != model.NumberOfFeatures {
+        return fmt.Errorf("the model has %d features but the samples have %d", model.NumberOfFeatures, numberOfFeatures)
+    }
+    featureNames := samples.featureNames()
+    if len(featureNames) == 0 || len(model.FeatureNames) == 0 {
+        return nil
+    }
+    for featureNumber, name := range featureNames {
+        if name != model.FeatureNames[featureNumber] {
+            return fmt.Errorf("feature %d is %s in the model but %s in the samples", featureNumber, model.FeatureNames[featureNumber], name)
+        }
+    }
+    return nil
+}
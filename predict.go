@@ -0,0 +1,197 @@
+package main
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/io"
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+    "encoding/csv"
+    "strings"
+    "strconv"
+    "errors"
+    "bufio"
+    "fmt"
+    goio "io"
+    "os"
+)
+
+// Copies each row of an unlabelled file to the output, appending its score, its label and,
+// optionally, its probability. Rows are scored as they are read, so files of any size can be streamed.
+func runPredict(args []string) error {
+    flagSet := newFlagSet("predict", "data.csv")
+    modelFilePath := flagSet.String("model", "", "file to read the model from")
+    format := flagSet.String("format", "", "model format: "+MODEL_FORMAT_USAGE)
+    outputFilePath := flagSet.String("output", "", "file to write the predictions to, the standard output when empty")
+    passthrough := flagSet.String("passthrough", "", "comma separated indexes of the columns that are not features, as IDs, copied to the output unparsed")
+    labelled := flagSet.Bool("labelled", false, "the label column, the last one unless -label-column is given, holds a label or a placeholder that is copied to the output but not used as a feature")
+    probability := flagSet.Bool("probability", false, "append the probability of the positive label")
+    datasetFlags := bindDatasetFlags(flagSet)
+    if err := parseArguments(flagSet, args, 1); err != nil {
+        return err
+    }
+    if *modelFilePath == "" {
+        return errors.New("the -model flag is needed")
+    }
+    datasetOptions, err := datasetFlags.options()
+    if err != nil {
+        return err
+    }
+    datasetOptions.Label = utils.NO_LABEL
+    if *labelled {
+        datasetOptions.Label = utils.SKIP_LABEL
+    }
+    passthroughColumns, err := parseColumns(*passthrough)
+    if err != nil {
+        return err
+    }
+    for _, column := range datasetOptions.IgnoredColumns {
+        delete(passthroughColumns, column)
+    }
+    for column := range passthroughColumns {
+        datasetOptions.IgnoredColumns = append(datasetOptions.IgnoredColumns, column)
+    }
+
+    model, err := loadModel(*modelFilePath, *format)
+    if err != nil {
+        return err
+    }
+    if model.Pipeline != nil {
+        datasetOptions.CategoryInputs = model.Pipeline.CategoryInputs()
+    }
+    inputFilePath := flagSet.Arg(0)
+    input, err := os.Open(inputFilePath)
+    if err != nil {
+        return utils.NewIOError("open", inputFilePath, err)
+    }
+    defer input.Close()
+    output := os.Stdout
+    if *outputFilePath != "" {
+        if output, err = os.Create(*outputFilePath); err != nil {
+            return utils.NewIOError("create", *outputFilePath, err)
+        }
+        defer output.Close()
+    }
+
+    predictor := &predictor{model: model, parser: utils.NewRecordParser(datasetOptions), probability: *probability}
+    reader := csv.NewReader(bufio.NewReader(input))
+    reader.Comma = datasetOptions.Delimiter
+    reader.FieldsPerRecord = -1
+    writer := csv.NewWriter(output)
+    writer.Comma = datasetOptions.Delimiter
+    if err := predictor.predict(reader, writer); err != nil {
+        if _, ok := err.(utils.RowErrors); ok {
+            return fmt.Errorf("%s: %w", inputFilePath, err)
+        }
+        return err
+    }
+    writer.Flush()
+    if err := writer.Error(); err != nil {
+        return utils.NewIOError("write", *outputFilePath, err)
+    }
+    return nil
+}
+
+// Scores the rows of a CSV reader with a model, writing them to a CSV writer. The rows are parsed as the other
+// commands read their CSV files, the label, ID, weight and ignored columns being copied but not used.
+type predictor struct {
+    model       io.Model
+    parser      *utils.RecordParser
+    probability bool
+}
+
+func (p *predictor) predict(reader *csv.Reader, writer *csv.Writer) error {
+    for row := 0; ; row++ {
+        record, err := reader.Read()
+        if err == goio.EOF {
+            if row == 0 {
+                return utils.ErrEmptyDataset
+            }
+            return nil
+        }
+        if err != nil {
+            return err
+        }
+        parsed, err := p.parser.Parse(record, row)
+        if _, ok := err.(utils.RowErrors); err != nil && !ok {
+            return err
+        }
+        if row == 0 {
+            if err := p.checkInputs(); err != nil {
+                return err
+            }
+        }
+        if err != nil {
+            return err
+        }
+        if row == 0 && p.parser.Summary().HasHeader {
+            if err := writer.Write(append(record, p.appendedColumnNames()...)); err != nil {
+                return err
+            }
+            continue
+        }
+        if parsed == nil {
+            continue
+        }
+        sample := parsed.Features
+        if p.model.Pipeline != nil {
+            sample = p.model.Pipeline.TransformSample(sample, parsed.Categories)
+        }
+        if err := writer.Write(append(record, p.score(sample)...)); err != nil {
+            return err
+        }
+    }
+}
+
+// Checks the rows have as many inputs as the model. The inputs are the features, or the inputs of its pipeline when
+// it has one.
+func (p *predictor) checkInputs() error {
+    summary := p.parser.Summary()
+    numberOfInputs := summary.Features + summary.Categories
+    if p.model.Pipeline != nil && numberOfInputs != p.model.Pipeline.NumberOfInputs {
+        return fmt.Errorf("the model has %d inputs but the samples have %d", p.model.Pipeline.NumberOfInputs, numberOfInputs)
+    }
+    if p.model.Pipeline == nil && uint(numberOfInputs) != p.model.NumberOfFeatures {
+        return fmt.Errorf("the model has %d features but the samples have %d", p.model.NumberOfFeatures, numberOfInputs)
+    }
+    return nil
+}
+
+func (p *predictor) appendedColumnNames() []string {
+    names := []string{"score", "label"}
+    if p.probability {
+        names = append(names, "probability")
+    }
+    return names
+}
+
+// Gets the score, the label name and, optionally, the probability of a sample.
+func (p *predictor) score(sample []float64) []string {
+    score := p.model.AdaBoost.Classify(sample)
+    label := -1
+    if score > 0 {
+        label = 1
+    }
+    name, ok := p.model.Labels[label]
+    if !ok {
+        name = strconv.Itoa(label)
+    }
+    values := []string{strconv.FormatFloat(score, 'g', -1, 64), name}
+    if p.probability {
+        values = append(values, strconv.FormatFloat(p.model.AdaBoost.PredictProba(sample), 'g', -1, 64))
+    }
+    return values
+}
+
+// Parses a comma separated list of column indexes.
+func parseColumns(value string) (map[int]bool, error) {
+    columns := map[int]bool{}
+    if value == "" {
+        return columns, nil
+    }
+    for _, field := range strings.Split(value, ",") {
+        column, err := strconv.Atoi(strings.TrimSpace(field))
+        if err != nil || column < 0 {
+            return nil, fmt.Errorf("invalid column index %q", field)
+        }
+        columns[column] = true
+    }
+    return columns, nil
+}
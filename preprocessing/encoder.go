@@ -0,0 +1,78 @@
+package preprocessing
+
+import (
+    "errors"
+    "math/rand"
+    "sort"
+    "fmt"
+)
+
+// Kinds of the encoders, as named by the command line and the model files.
+const (
+    ONE_HOT_ENCODER = "one-hot"
+    ORDINAL_ENCODER = "ordinal"
+    TARGET_ENCODER = "target"
+)
+
+var ErrUnknownEncoder = errors.New("unknown encoder")
+
+// An encoder turns the categories of a column into features. The missing category is the empty one.
+type Encoder interface {
+
+    // Learns the categories of the training samples, given their labels, and encodes them. The random choices of the
+    // fitting, as the folds of the target encoder, are drawn from random.
+    FitTransform(categories []string, labels []float64, random *rand.Rand) [][]float64
+
+    // Encodes a category with what was learned by FitTransform.
+    Transform(category string) []float64
+
+    // Gets the names of the features a column is encoded into.
+    FeatureNames(column string) []string
+
+    Kind() string
+}
+
+// Creates an encoder of a kind, with the default parameters.
+func NewEncoder(kind string) (Encoder, error) {
+    switch kind {
+    case ONE_HOT_ENCODER:
+        return &OneHotEncoder{}, nil
+    case ORDINAL_ENCODER:
+        return &OrdinalEncoder{}, nil
+    case TARGET_ENCODER:
+        return NewTargetEncoder(DEFAULT_SMOOTHING, DEFAULT_NUMBER_OF_FOLDS), nil
+    }
+    return nil, fmt.Errorf("%w: %s", ErrUnknownEncoder, kind)
+}
+
+// Gets the distinct categories, but the missing one, in increasing order.
+func distinctCategories(categories []string) []string {
+    seen := map[string]bool{"": true}
+    distinct := []string{}
+    for _, category := range categories {
+        if !seen[category] {
+            seen[category] = true
+            distinct = append(distinct, category)
+        }
+    }
+    sort.Strings(distinct)
+    return distinct
+}
+
+// Gets the position of a category among the sorted ones, -1 when it is not one of them.
+func indexOf(categories []string, category string) int {
+    i := sort.SearchStrings(categories, category)
+    if i < len(categories) && categories[i] == category {
+        return i
+    }
+    return -1
+}
+
+// Encodes each category with an encoder that is already fitted.
+func transformAll(encoder Encoder, categories []string) [][]float64 {
+    encoded := make([][]float64, len(categories))
+    for i, category := range categories {
+        encoded[i] = encoder.Transform(category)
+    }
+    return encoded
+}
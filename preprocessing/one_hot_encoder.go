@@ -0,0 +1,46 @@
+package preprocessing
+
+import (
+    "math"
+    "math/rand"
+)
+
+// Encodes a category into one feature per training category, which is 1 for its category and 0 for the others.
+// Categories unseen in training get all 0, and the missing one all NaN.
+type OneHotEncoder struct {
+
+    // Categories of the training samples, in increasing order.
+    Categories []string
+}
+
+func (e *OneHotEncoder) FitTransform(categories []string, labels []float64, random *rand.Rand) [][]float64 {
+    e.Categories = distinctCategories(categories)
+    return transformAll(e, categories)
+}
+
+func (e *OneHotEncoder) Transform(category string) []float64 {
+    encoded := make([]float64, len(e.Categories))
+    if category == "" {
+        for i := range encoded {
+            encoded[i] = math.NaN()
+        }
+        return encoded
+    }
+    if i := indexOf(e.Categories, category); i >= 0 {
+        encoded[i] = 1
+    }
+    return encoded
+}
+
+// The features are named column=category.
+func (e *OneHotEncoder) FeatureNames(column string) []string {
+    names := make([]string, len(e.Categories))
+    for i, category := range e.Categories {
+        names[i] = column + "=" + category
+    }
+    return names
+}
+
+func (e *OneHotEncoder) Kind() string {
+    return ONE_HOT_ENCODER
+}
@@ -0,0 +1,36 @@
+package preprocessing
+
+import (
+    "math"
+    "math/rand"
+)
+
+// Encodes a category into a single feature holding its position among the training categories, which stumps split
+// into subsets when it is one of the categorical features. Missing categories and those unseen in training are NaN,
+// following the direction learned for the missing values.
+type OrdinalEncoder struct {
+
+    // Categories of the training samples, in increasing order.
+    Categories []string
+}
+
+func (e *OrdinalEncoder) FitTransform(categories []string, labels []float64, random *rand.Rand) [][]float64 {
+    e.Categories = distinctCategories(categories)
+    return transformAll(e, categories)
+}
+
+func (e *OrdinalEncoder) Transform(category string) []float64 {
+    i := indexOf(e.Categories, category)
+    if category == "" || i < 0 {
+        return []float64{math.NaN()}
+    }
+    return []float64{float64(i)}
+}
+
+func (e *OrdinalEncoder) FeatureNames(column string) []string {
+    return []string{column}
+}
+
+func (e *OrdinalEncoder) Kind() string {
+    return ORDINAL_ENCODER
+}
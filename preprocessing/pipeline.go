@@ -0,0 +1,137 @@
+package preprocessing
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
+    "strconv"
+    "math/rand"
+    "fmt"
+)
+
+// Encoder of a category column.
+type ColumnEncoder struct {
+
+    // Position of the column among the inputs.
+    Input   int
+    Name    string
+    Encoder Encoder
+}
+
+// Turns the inputs of a sample, its features and its category columns, into the features a classifier learns from:
+// the features followed by the encodings of the category columns, in the order of the inputs.
+//
+// It is fitted on the training set and saved with the model, so the samples to classify are encoded the same way.
+type Pipeline struct {
+    NumberOfInputs int
+    Encoders       []ColumnEncoder
+}
+
+// Fits a pipeline on a dataset, encoding each of its category columns with the given encoder, and gets the encoded
+// dataset. The encoders draw their random choices from random, so the same seed gives the same encoding.
+func FitPipeline(dataset *utils.Dataset, encoders []Encoder, random *rand.Rand) (*Pipeline, *utils.Dataset, error) {
+    if len(encoders) != len(dataset.CategoryInputs) {
+        return nil, nil, fmt.Errorf("%w: %d encoders for %d category columns", utils.ErrInvalidColumn, len(encoders), len(dataset.CategoryInputs))
+    }
+    p := &Pipeline{NumberOfInputs: dataset.NumberOfFeatures() + len(dataset.CategoryInputs)}
+    labels := make([]float64, dataset.NumberOfSamples())
+    for i, sample := range dataset.Samples {
+        labels[i] = sample[len(sample) - 1]
+    }
+    encoded := make([][][]float64, len(encoders))
+    for j, encoder := range encoders {
+        name := "c" + strconv.Itoa(dataset.CategoryInputs[j])
+        if dataset.CategoryNames != nil {
+            name = dataset.CategoryNames[j]
+        }
+        p.Encoders = append(p.Encoders, ColumnEncoder{Input: dataset.CategoryInputs[j], Name: name, Encoder: encoder})
+        encoded[j] = encoder.FitTransform(column(dataset.Categories, j), labels, random)
+    }
+    return p, p.build(dataset, func(i, j int) []float64 {
+        return encoded[j][i]
+    }), nil
+}
+
+// Encodes a dataset having the category columns the pipeline was fitted on.
+func (p *Pipeline) Transform(dataset *utils.Dataset) (*utils.Dataset, error) {
+    if err := p.check(dataset); err != nil {
+        return nil, err
+    }
+    return p.build(dataset, func(i, j int) []float64 {
+        return p.Encoders[j].Encoder.Transform(dataset.Categories[i][j])
+    }), nil
+}
+
+// Encodes a sample given its features, without label, and its categories.
+func (p *Pipeline) TransformSample(features []float64, categories []string) []float64 {
+    sample := append([]float64{}, features...)
+    for j, columnEncoder := range p.Encoders {
+        sample = append(sample, columnEncoder.Encoder.Transform(categories[j])...)
+    }
+    return sample
+}
+
+// Gets the positions of the category columns among the inputs.
+func (p *Pipeline) CategoryInputs() []int {
+    inputs := make([]int, len(p.Encoders))
+    for j, columnEncoder := range p.Encoders {
+        inputs[j] = columnEncoder.Input
+    }
+    return inputs
+}
+
+// Gets the numbers of the encoded features holding ordinal categories, to be split into subsets.
+func (p *Pipeline) CategoricalFeatures() []uint {
+    featureNumbers := []uint{}
+    featureNumber := uint(p.NumberOfInputs - len(p.Encoders))
+    for _, columnEncoder := range p.Encoders {
+        if columnEncoder.Encoder.Kind() == ORDINAL_ENCODER {
+            featureNumbers = append(featureNumbers, featureNumber)
+        }
+        featureNumber += uint(len(columnEncoder.Encoder.FeatureNames(columnEncoder.Name)))
+    }
+    return featureNumbers
+}
+
+// Checks a dataset has the inputs of the pipeline.
+func (p *Pipeline) check(dataset *utils.Dataset) error {
+    if len(dataset.CategoryInputs) != len(p.Encoders) {
+        return fmt.Errorf("%w: the pipeline has %d category columns but the samples have %d", utils.ErrInvalidColumn, len(p.Encoders), len(dataset.CategoryInputs))
+    }
+    for j, input := range dataset.CategoryInputs {
+        if input != p.Encoders[j].Input {
+            return fmt.Errorf("%w: category column %d is input %d in the pipeline but %d in the samples", utils.ErrInvalidColumn, j, p.Encoders[j].Input, input)
+        }
+    }
+    if numberOfInputs := dataset.NumberOfFeatures() + len(dataset.CategoryInputs); dataset.NumberOfSamples() > 0 && numberOfInputs != p.NumberOfInputs {
+        return fmt.Errorf("%w: the pipeline has %d inputs but the samples have %d", utils.ErrInvalidColumn, p.NumberOfInputs, numberOfInputs)
+    }
+    return nil
+}
+
+// Builds the encoded dataset, getting the encoding of category column j of sample i from encode.
+func (p *Pipeline) build(dataset *utils.Dataset, encode func(i, j int) []float64) *utils.Dataset {
+    encoded := &utils.Dataset{Samples: make([][]float64, dataset.NumberOfSamples()), IDs: dataset.IDs, Weights: dataset.Weights}
+    if dataset.FeatureNames != nil {
+        encoded.FeatureNames = append([]string{}, dataset.FeatureNames...)
+        for _, columnEncoder := range p.Encoders {
+            encoded.FeatureNames = append(encoded.FeatureNames, columnEncoder.Encoder.FeatureNames(columnEncoder.Name)...)
+        }
+    }
+    for i, sample := range dataset.Samples {
+        label := len(sample) - 1
+        encodedSample := append([]float64{}, sample[:label]...)
+        for j := range p.Encoders {
+            encodedSample = append(encodedSample, encode(i, j)...)
+        }
+        encoded.Samples[i] = append(encodedSample, sample[label])
+    }
+    return encoded
+}
+
+// Gets column j of the categories.
+func column(categories [][]string, j int) []string {
+    values := make([]string, len(categories))
+    for i, row := range categories {
+        values[i] = row[j]
+    }
+    return values
+}
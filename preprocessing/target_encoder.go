@@ -0,0 +1,108 @@
+package preprocessing
+
+import (
+    "math/rand"
+    "math"
+)
+
+const (
+    DEFAULT_SMOOTHING = 10.0
+    DEFAULT_NUMBER_OF_FOLDS = 5
+)
+
+// Encodes a category into a single feature holding the mean label of its training samples, smoothed towards the mean
+// label of all of them, the prior, so rare categories are not trusted more than their counts allow:
+// e_{c} = \frac{\sum_{i \in c} y_{i} + m \bar{y}}{n_{c} + m}
+// Categories unseen in training get the prior, and the missing one NaN.
+type TargetEncoder struct {
+
+    // Categories of the training samples, in increasing order, and their encodings.
+    Categories []string
+    Values     []float64
+
+    Prior     float64
+    Smoothing float64
+
+    // The training samples are split into this many folds, and those of each fold are encoded with what was learned
+    // from the others, so their encodings do not hold their own labels. No folds are made when it is less than 2.
+    NumberOfFolds int
+}
+
+func NewTargetEncoder(smoothing float64, numberOfFolds int) *TargetEncoder {
+    return &TargetEncoder{Smoothing: smoothing, NumberOfFolds: numberOfFolds}
+}
+
+func (e *TargetEncoder) FitTransform(categories []string, labels []float64, random *rand.Rand) [][]float64 {
+    all := make([]bool, len(categories))
+    for i := range all {
+        all[i] = true
+    }
+    e.Categories, e.Values, e.Prior = e.fit(categories, labels, all)
+    if e.NumberOfFolds < 2 || len(categories) < e.NumberOfFolds {
+        return transformAll(e, categories)
+    }
+    folds := random.Perm(len(categories))
+    encoded := make([][]float64, len(categories))
+    for fold := 0; fold < e.NumberOfFolds; fold++ {
+        inFit := make([]bool, len(categories))
+        for i := range inFit {
+            inFit[i] = folds[i] % e.NumberOfFolds != fold
+        }
+        outOfFold := &TargetEncoder{Smoothing: e.Smoothing}
+        outOfFold.Categories, outOfFold.Values, outOfFold.Prior = e.fit(categories, labels, inFit)
+        for i, category := range categories {
+            if !inFit[i] {
+                encoded[i] = outOfFold.Transform(category)
+            }
+        }
+    }
+    return encoded
+}
+
+// Learns the encodings of the categories of the samples to fit, and their prior.
+func (e *TargetEncoder) fit(categories []string, labels []float64, inFit []bool) ([]string, []float64, float64) {
+    distinct := distinctCategories(categories)
+    sums := make([]float64, len(distinct))
+    counts := make([]float64, len(distinct))
+    prior, n := 0.0, 0.0
+    for i, category := range categories {
+        if !inFit[i] {
+            continue
+        }
+        prior += labels[i]
+        n++
+        if c := indexOf(distinct, category); c >= 0 {
+            sums[c] += labels[i]
+            counts[c]++
+        }
+    }
+    if n > 0 {
+        prior /= n
+    }
+    values := make([]float64, len(distinct))
+    for c := range values {
+        values[c] = (sums[c] + e.Smoothing * prior) / (counts[c] + e.Smoothing)
+        if counts[c] + e.Smoothing == 0 {
+            values[c] = prior
+        }
+    }
+    return distinct, values, prior
+}
+
+func (e *TargetEncoder) Transform(category string) []float64 {
+    if category == "" {
+        return []float64{math.NaN()}
+    }
+    if i := indexOf(e.Categories, category); i >= 0 {
+        return []float64{e.Values[i]}
+    }
+    return []float64{e.Prior}
+}
+
+func (e *TargetEncoder) FeatureNames(column string) []string {
+    return []string{column}
+}
+
+func (e *TargetEncoder) Kind() string {
+    return TARGET_ENCODER
+}
@@ -0,0 +1,60 @@
+package preprocessing
+
+import (
+    "math"
+    "math/rand"
+    "reflect"
+    "testing"
+)
+
+func TestTargetEncoderFitTransform(t *testing.T) {
+    categories := []string{"a", "b", "a", "c", "b", "a", "a", "b", "c", "a"}
+    labels := []float64{1, -1, 1, -1, 1, -1, 1, -1, -1, 1}
+    tests := []struct {
+        name          string
+        smoothing     float64
+        numberOfFolds int
+    }{
+        {"no folds", DEFAULT_SMOOTHING, 0},
+        {"two folds", DEFAULT_SMOOTHING, 2},
+        {"default folds", DEFAULT_SMOOTHING, DEFAULT_NUMBER_OF_FOLDS},
+        {"leave one out without smoothing", 0, len(categories)},
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            encoder := NewTargetEncoder(test.smoothing, test.numberOfFolds)
+            encoded := encoder.FitTransform(categories, labels, rand.New(rand.NewSource(7)))
+
+            // The same seed draws the same folds.
+            again := NewTargetEncoder(test.smoothing, test.numberOfFolds).FitTransform(categories, labels, rand.New(rand.NewSource(7)))
+            if !reflect.DeepEqual(encoded, again) {
+                t.Errorf("encoded %v, then %v with the same seed", encoded, again)
+            }
+
+            // The encodings learned from all the samples are smoothed towards the prior, their mean label.
+            if encoder.Prior != 0 {
+                t.Errorf("prior is %v, want 0", encoder.Prior)
+            }
+            if want := 3 / (5 + test.smoothing); math.Abs(encoder.Transform("a")[0] - want) > 1e-12 {
+                t.Errorf("encoding of a is %v, want %v", encoder.Transform("a")[0], want)
+            }
+
+            // Leaving a single sample out, its encoding is the mean label of the other samples of its category.
+            if test.numberOfFolds != len(categories) {
+                return
+            }
+            for i, category := range categories {
+                sum, count := 0.0, 0.0
+                for j := range categories {
+                    if j != i && categories[j] == category {
+                        sum += labels[j]
+                        count++
+                    }
+                }
+                if want := sum / count; math.Abs(encoded[i][0] - want) > 1e-12 {
+                    t.Errorf("sample %d: encoded %v, want %v", i, encoded[i][0], want)
+                }
+            }
+        })
+    }
+}
@@ -2,6 +2,7 @@ package resample
 
 import (
     "github.com/dalmirdasilva/AdaBoostGo/statistics"
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
     "math"
 )
 
@@ -13,29 +14,77 @@ func NewResampler() Resampler {
     return Resampler{}
 }
 
-func (r *Resampler) OverSample(samples [][]float64) [][]float64 {
-    distribution := r.getClassDistribution(samples)
-    y0 := distribution.Negative
-    y1 := distribution.Positive
+// Appends samples of the minority class until both classes have the same number of samples.
+func (r *Resampler) OverSample(samples [][]float64) ([][]float64, error) {
+    distribution, err := r.getClassDistribution(samples)
+    if err != nil {
+        return nil, err
+    }
+    labels := make([]float64, len(samples))
+    for i, sample := range samples {
+        labels[i] = sample[len(sample) - 1]
+    }
+    for _, row := range r.minorityRows(labels, distribution) {
+        samples = append(samples, samples[row])
+    }
+    return samples, nil
+}
+
+// Appends samples of the minority class of sparse samples until both classes have the same number of samples.
+func (r *Resampler) OverSampleSparse(samples *utils.SparseSamples) (*utils.SparseSamples, error) {
+    analyzer := statistics.NewFeaturesAnalyzer()
+    _, distribution, err := analyzer.AnalyzeSparse(samples)
+    if err != nil {
+        return nil, err
+    }
+    return samples.Select(appendRows(samples.NumberOfSamples(), r.minorityRows(samples.Labels, distribution))), nil
+}
+
+// Appends samples of the minority class of a dataset, with their IDs and weights, until both classes have the same
+// number of samples.
+func (r *Resampler) OverSampleDataset(dataset *utils.Dataset) (*utils.Dataset, error) {
+    distribution, err := r.getClassDistribution(dataset.Samples)
+    if err != nil {
+        return nil, err
+    }
+    labels := make([]float64, dataset.NumberOfSamples())
+    for i, sample := range dataset.Samples {
+        labels[i] = sample[len(sample) - 1]
+    }
+    return dataset.Select(appendRows(dataset.NumberOfSamples(), r.minorityRows(labels, distribution))), nil
+}
+
+// Gets the rows to append to balance the classes, the first ones of the minority class.
+func (r *Resampler) minorityRows(labels []float64, distribution statistics.ClassDistribution) []int {
     majority := -1.0
-    if y0 < y1 {
+    if distribution.Negative < distribution.Positive {
         majority = 1.0
     }
-    difference := math.Abs(float64(y0) - float64(y1))
-    for _, sample := range samples {
+    difference := math.Abs(float64(distribution.Negative) - float64(distribution.Positive))
+    rows := []int{}
+    for i, label := range labels {
         if difference <= 0 {
             break
         }
-        if sample[len(sample) - 1] != majority {
-            samples = append(samples, sample)
+        if label != majority {
+            rows = append(rows, i)
             difference--
         }
     }
-    return samples
+    return rows
+}
+
+// Gets the rows of all the samples followed by the appended ones.
+func appendRows(numberOfSamples int, appended []int) []int {
+    rows := make([]int, numberOfSamples, numberOfSamples + len(appended))
+    for i := range rows {
+        rows[i] = i
+    }
+    return append(rows, appended...)
 }
 
-func (r *Resampler) getClassDistribution(instances [][]float64) statistics.ClassDistribution {
+func (r *Resampler) getClassDistribution(instances [][]float64) (statistics.ClassDistribution, error) {
     analyzer := statistics.NewFeaturesAnalyzer()
-    _, distribution := analyzer.Analyze(instances)
-    return distribution
+    _, distribution, err := analyzer.Analyze(instances)
+    return distribution, err
 }
\ No newline at end of file
@@ -6,11 +6,29 @@
 Package dom_distiller is a generated protocol buffer package.
 
 It is generated from these files:
+
 	adaboost.proto
+	model.proto
 
 It has these top-level messages:
+
 	AdaBoostProto
 	StumpProto
+	ModelProto
+	LabelProto
+	OptionsProto
+	ClassCountProto
+	EvaluationProto
+	PipelineProto
+	EncoderProto
+	MultiClassAdaBoostProto
+	MultiClassStumpProto
+	MultiLabelAdaBoostProto
+	MultiLabelStumpProto
+	RegressorAdaBoostProto
+	RegressorProto
+	RegressionTreeProto
+	RegressionNodeProto
 */
 package dom_distiller
 
@@ -27,6 +45,7 @@ type AdaBoostProto struct {
 	NumStumps        *int32        `protobuf:"varint,1,req,name=num_stumps" json:"num_stumps,omitempty"`
 	NumFeatures      *int32        `protobuf:"varint,2,req,name=num_features" json:"num_features,omitempty"`
 	Stump            []*StumpProto `protobuf:"bytes,3,rep,name=stump" json:"stump,omitempty"`
+	Mode             *string       `protobuf:"bytes,4,opt,name=mode,def=discrete" json:"mode,omitempty"`
 	XXX_unrecognized []byte        `json:"-"`
 }
 
@@ -34,6 +53,8 @@ func (m *AdaBoostProto) Reset()         { *m = AdaBoostProto{} }
 func (m *AdaBoostProto) String() string { return proto.CompactTextString(m) }
 func (*AdaBoostProto) ProtoMessage()    {}
 
+const Default_AdaBoostProto_Mode string = "discrete"
+
 func (m *AdaBoostProto) GetNumStumps() int32 {
 	if m != nil && m.NumStumps != nil {
 		return *m.NumStumps
@@ -55,17 +76,32 @@ func (m *AdaBoostProto) GetStump() []*StumpProto {
 	return nil
 }
 
+func (m *AdaBoostProto) GetMode() string {
+	if m != nil && m.Mode != nil {
+		return *m.Mode
+	}
+	return Default_AdaBoostProto_Mode
+}
+
 type StumpProto struct {
-	FeatureNumber    *int32   `protobuf:"varint,1,req,name=feature_number" json:"feature_number,omitempty"`
-	Split            *float64 `protobuf:"fixed64,2,req,name=split" json:"split,omitempty"`
-	Weight           *float64 `protobuf:"fixed64,3,req,name=weight" json:"weight,omitempty"`
-	XXX_unrecognized []byte   `json:"-"`
+	FeatureNumber    *int32    `protobuf:"varint,1,req,name=feature_number" json:"feature_number,omitempty"`
+	Split            *float64  `protobuf:"fixed64,2,req,name=split" json:"split,omitempty"`
+	Weight           *float64  `protobuf:"fixed64,3,req,name=weight" json:"weight,omitempty"`
+	Polarity         *int32    `protobuf:"varint,4,opt,name=polarity,def=1" json:"polarity,omitempty"`
+	LeftValue        *float64  `protobuf:"fixed64,5,opt,name=left_value" json:"left_value,omitempty"`
+	RightValue       *float64  `protobuf:"fixed64,6,opt,name=right_value" json:"right_value,omitempty"`
+	MissingAbove     *bool     `protobuf:"varint,7,opt,name=missing_above,def=0" json:"missing_above,omitempty"`
+	Category         []float64 `protobuf:"fixed64,8,rep,name=category" json:"category,omitempty"`
+	XXX_unrecognized []byte    `json:"-"`
 }
 
 func (m *StumpProto) Reset()         { *m = StumpProto{} }
 func (m *StumpProto) String() string { return proto.CompactTextString(m) }
 func (*StumpProto) ProtoMessage()    {}
 
+const Default_StumpProto_Polarity int32 = 1
+const Default_StumpProto_MissingAbove bool = false
+
 func (m *StumpProto) GetFeatureNumber() int32 {
 	if m != nil && m.FeatureNumber != nil {
 		return *m.FeatureNumber
@@ -86,3 +122,38 @@ func (m *StumpProto) GetWeight() float64 {
 	}
 	return 0
 }
+
+func (m *StumpProto) GetPolarity() int32 {
+	if m != nil && m.Polarity != nil {
+		return *m.Polarity
+	}
+	return Default_StumpProto_Polarity
+}
+
+func (m *StumpProto) GetLeftValue() float64 {
+	if m != nil && m.LeftValue != nil {
+		return *m.LeftValue
+	}
+	return 0
+}
+
+func (m *StumpProto) GetRightValue() float64 {
+	if m != nil && m.RightValue != nil {
+		return *m.RightValue
+	}
+	return 0
+}
+
+func (m *StumpProto) GetMissingAbove() bool {
+	if m != nil && m.MissingAbove != nil {
+		return *m.MissingAbove
+	}
+	return Default_StumpProto_MissingAbove
+}
+
+func (m *StumpProto) GetCategory() []float64 {
+	if m != nil {
+		return m.Category
+	}
+	return nil
+}
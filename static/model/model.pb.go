@@ -0,0 +1,770 @@
+// Code generated by protoc-gen-go.
+// source: model.proto
+// DO NOT EDIT!
+
+package dom_distiller
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type ModelProto struct {
+	FormatVersion    *int32             `protobuf:"varint,1,req,name=format_version" json:"format_version,omitempty"`
+	AdaBoost         *AdaBoostProto     `protobuf:"bytes,2,req,name=ada_boost" json:"ada_boost,omitempty"`
+	FeatureName      []string           `protobuf:"bytes,3,rep,name=feature_name" json:"feature_name,omitempty"`
+	Label            []*LabelProto      `protobuf:"bytes,4,rep,name=label" json:"label,omitempty"`
+	Options          *OptionsProto      `protobuf:"bytes,5,opt,name=options" json:"options,omitempty"`
+	ClassCount       []*ClassCountProto `protobuf:"bytes,6,rep,name=class_count" json:"class_count,omitempty"`
+	TrainingError    []float64          `protobuf:"fixed64,7,rep,name=training_error" json:"training_error,omitempty"`
+	Evaluation       *EvaluationProto   `protobuf:"bytes,8,opt,name=evaluation" json:"evaluation,omitempty"`
+	Checksum         *string            `protobuf:"bytes,9,opt,name=checksum" json:"checksum,omitempty"`
+	Pipeline         *PipelineProto     `protobuf:"bytes,10,opt,name=pipeline" json:"pipeline,omitempty"`
+	ValidationMetric []float64          `protobuf:"fixed64,11,rep,name=validation_metric" json:"validation_metric,omitempty"`
+	XXX_unrecognized []byte             `json:"-"`
+}
+
+func (m *ModelProto) Reset()         { *m = ModelProto{} }
+func (m *ModelProto) String() string { return proto.CompactTextString(m) }
+func (*ModelProto) ProtoMessage()    {}
+
+func (m *ModelProto) GetFormatVersion() int32 {
+	if m != nil && m.FormatVersion != nil {
+		return *m.FormatVersion
+	}
+	return 0
+}
+
+func (m *ModelProto) GetAdaBoost() *AdaBoostProto {
+	if m != nil {
+		return m.AdaBoost
+	}
+	return nil
+}
+
+func (m *ModelProto) GetFeatureName() []string {
+	if m != nil {
+		return m.FeatureName
+	}
+	return nil
+}
+
+func (m *ModelProto) GetLabel() []*LabelProto {
+	if m != nil {
+		return m.Label
+	}
+	return nil
+}
+
+func (m *ModelProto) GetOptions() *OptionsProto {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *ModelProto) GetClassCount() []*ClassCountProto {
+	if m != nil {
+		return m.ClassCount
+	}
+	return nil
+}
+
+func (m *ModelProto) GetTrainingError() []float64 {
+	if m != nil {
+		return m.TrainingError
+	}
+	return nil
+}
+
+func (m *ModelProto) GetEvaluation() *EvaluationProto {
+	if m != nil {
+		return m.Evaluation
+	}
+	return nil
+}
+
+func (m *ModelProto) GetChecksum() string {
+	if m != nil && m.Checksum != nil {
+		return *m.Checksum
+	}
+	return ""
+}
+
+func (m *ModelProto) GetPipeline() *PipelineProto {
+	if m != nil {
+		return m.Pipeline
+	}
+	return nil
+}
+
+func (m *ModelProto) GetValidationMetric() []float64 {
+	if m != nil {
+		return m.ValidationMetric
+	}
+	return nil
+}
+
+type LabelProto struct {
+	Value            *int32  `protobuf:"varint,1,req,name=value" json:"value,omitempty"`
+	Name             *string `protobuf:"bytes,2,req,name=name" json:"name,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
+}
+
+func (m *LabelProto) Reset()         { *m = LabelProto{} }
+func (m *LabelProto) String() string { return proto.CompactTextString(m) }
+func (*LabelProto) ProtoMessage()    {}
+
+func (m *LabelProto) GetValue() int32 {
+	if m != nil && m.Value != nil {
+		return *m.Value
+	}
+	return 0
+}
+
+func (m *LabelProto) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+type OptionsProto struct {
+	NumberOfClassifiers              *uint32  `protobuf:"varint,1,opt,name=number_of_classifiers" json:"number_of_classifiers,omitempty"`
+	UseRandomWeakClassifiers         *bool    `protobuf:"varint,2,opt,name=use_random_weak_classifiers" json:"use_random_weak_classifiers,omitempty"`
+	NumberOfRandomClassifiers        *int32   `protobuf:"varint,3,opt,name=number_of_random_classifiers" json:"number_of_random_classifiers,omitempty"`
+	IncorporateCostSensitiveLearning *bool    `protobuf:"varint,4,opt,name=incorporate_cost_sensitive_learning" json:"incorporate_cost_sensitive_learning,omitempty"`
+	UseThresholdClassification       *bool    `protobuf:"varint,5,opt,name=use_threshold_classification" json:"use_threshold_classification,omitempty"`
+	OverSamplingTrainingSet          *bool    `protobuf:"varint,6,opt,name=over_sampling_training_set" json:"over_sampling_training_set,omitempty"`
+	TestPercent                      *float64 `protobuf:"fixed64,7,opt,name=test_percent" json:"test_percent,omitempty"`
+	NumberOfWorkers                  *int32   `protobuf:"varint,8,opt,name=number_of_workers" json:"number_of_workers,omitempty"`
+	CategoricalFeature               []uint32 `protobuf:"varint,9,rep,name=categorical_feature" json:"categorical_feature,omitempty"`
+	ValidationPercent                *float64 `protobuf:"fixed64,10,opt,name=validation_percent" json:"validation_percent,omitempty"`
+	EarlyStoppingRounds              *uint32  `protobuf:"varint,11,opt,name=early_stopping_rounds" json:"early_stopping_rounds,omitempty"`
+	EarlyStoppingMetric              *string  `protobuf:"bytes,12,opt,name=early_stopping_metric" json:"early_stopping_metric,omitempty"`
+	LearningRate                     *float64 `protobuf:"fixed64,13,opt,name=learning_rate,def=1" json:"learning_rate,omitempty"`
+	XXX_unrecognized                 []byte   `json:"-"`
+}
+
+func (m *OptionsProto) Reset()         { *m = OptionsProto{} }
+func (m *OptionsProto) String() string { return proto.CompactTextString(m) }
+func (*OptionsProto) ProtoMessage()    {}
+
+const Default_OptionsProto_LearningRate float64 = 1
+
+func (m *OptionsProto) GetNumberOfClassifiers() uint32 {
+	if m != nil && m.NumberOfClassifiers != nil {
+		return *m.NumberOfClassifiers
+	}
+	return 0
+}
+
+func (m *OptionsProto) GetUseRandomWeakClassifiers() bool {
+	if m != nil && m.UseRandomWeakClassifiers != nil {
+		return *m.UseRandomWeakClassifiers
+	}
+	return false
+}
+
+func (m *OptionsProto) GetNumberOfRandomClassifiers() int32 {
+	if m != nil && m.NumberOfRandomClassifiers != nil {
+		return *m.NumberOfRandomClassifiers
+	}
+	return 0
+}
+
+func (m *OptionsProto) GetIncorporateCostSensitiveLearning() bool {
+	if m != nil && m.IncorporateCostSensitiveLearning != nil {
+		return *m.IncorporateCostSensitiveLearning
+	}
+	return false
+}
+
+func (m *OptionsProto) GetUseThresholdClassification() bool {
+	if m != nil && m.UseThresholdClassification != nil {
+		return *m.UseThresholdClassification
+	}
+	return false
+}
+
+func (m *OptionsProto) GetOverSamplingTrainingSet() bool {
+	if m != nil && m.OverSamplingTrainingSet != nil {
+		return *m.OverSamplingTrainingSet
+	}
+	return false
+}
+
+func (m *OptionsProto) GetTestPercent() float64 {
+	if m != nil && m.TestPercent != nil {
+		return *m.TestPercent
+	}
+	return 0
+}
+
+func (m *OptionsProto) GetNumberOfWorkers() int32 {
+	if m != nil && m.NumberOfWorkers != nil {
+		return *m.NumberOfWorkers
+	}
+	return 0
+}
+
+func (m *OptionsProto) GetCategoricalFeature() []uint32 {
+	if m != nil {
+		return m.CategoricalFeature
+	}
+	return nil
+}
+
+func (m *OptionsProto) GetValidationPercent() float64 {
+	if m != nil && m.ValidationPercent != nil {
+		return *m.ValidationPercent
+	}
+	return 0
+}
+
+func (m *OptionsProto) GetEarlyStoppingRounds() uint32 {
+	if m != nil && m.EarlyStoppingRounds != nil {
+		return *m.EarlyStoppingRounds
+	}
+	return 0
+}
+
+func (m *OptionsProto) GetEarlyStoppingMetric() string {
+	if m != nil && m.EarlyStoppingMetric != nil {
+		return *m.EarlyStoppingMetric
+	}
+	return ""
+}
+
+func (m *OptionsProto) GetLearningRate() float64 {
+	if m != nil && m.LearningRate != nil {
+		return *m.LearningRate
+	}
+	return Default_OptionsProto_LearningRate
+}
+
+type ClassCountProto struct {
+	Label            *int32  `protobuf:"varint,1,req,name=label" json:"label,omitempty"`
+	Count            *uint32 `protobuf:"varint,2,req,name=count" json:"count,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
+}
+
+func (m *ClassCountProto) Reset()         { *m = ClassCountProto{} }
+func (m *ClassCountProto) String() string { return proto.CompactTextString(m) }
+func (*ClassCountProto) ProtoMessage()    {}
+
+func (m *ClassCountProto) GetLabel() int32 {
+	if m != nil && m.Label != nil {
+		return *m.Label
+	}
+	return 0
+}
+
+func (m *ClassCountProto) GetCount() uint32 {
+	if m != nil && m.Count != nil {
+		return *m.Count
+	}
+	return 0
+}
+
+type EvaluationProto struct {
+	TruePositive     *uint32 `protobuf:"varint,1,req,name=true_positive" json:"true_positive,omitempty"`
+	FalsePositive    *uint32 `protobuf:"varint,2,req,name=false_positive" json:"false_positive,omitempty"`
+	TrueNegative     *uint32 `protobuf:"varint,3,req,name=true_negative" json:"true_negative,omitempty"`
+	FalseNegative    *uint32 `protobuf:"varint,4,req,name=false_negative" json:"false_negative,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
+}
+
+func (m *EvaluationProto) Reset()         { *m = EvaluationProto{} }
+func (m *EvaluationProto) String() string { return proto.CompactTextString(m) }
+func (*EvaluationProto) ProtoMessage()    {}
+
+func (m *EvaluationProto) GetTruePositive() uint32 {
+	if m != nil && m.TruePositive != nil {
+		return *m.TruePositive
+	}
+	return 0
+}
+
+func (m *EvaluationProto) GetFalsePositive() uint32 {
+	if m != nil && m.FalsePositive != nil {
+		return *m.FalsePositive
+	}
+	return 0
+}
+
+func (m *EvaluationProto) GetTrueNegative() uint32 {
+	if m != nil && m.TrueNegative != nil {
+		return *m.TrueNegative
+	}
+	return 0
+}
+
+func (m *EvaluationProto) GetFalseNegative() uint32 {
+	if m != nil && m.FalseNegative != nil {
+		return *m.FalseNegative
+	}
+	return 0
+}
+
+type PipelineProto struct {
+	NumInputs        *uint32         `protobuf:"varint,1,req,name=num_inputs" json:"num_inputs,omitempty"`
+	Encoder          []*EncoderProto `protobuf:"bytes,2,rep,name=encoder" json:"encoder,omitempty"`
+	XXX_unrecognized []byte          `json:"-"`
+}
+
+func (m *PipelineProto) Reset()         { *m = PipelineProto{} }
+func (m *PipelineProto) String() string { return proto.CompactTextString(m) }
+func (*PipelineProto) ProtoMessage()    {}
+
+func (m *PipelineProto) GetNumInputs() uint32 {
+	if m != nil && m.NumInputs != nil {
+		return *m.NumInputs
+	}
+	return 0
+}
+
+func (m *PipelineProto) GetEncoder() []*EncoderProto {
+	if m != nil {
+		return m.Encoder
+	}
+	return nil
+}
+
+type EncoderProto struct {
+	Kind             *string   `protobuf:"bytes,1,req,name=kind" json:"kind,omitempty"`
+	Input            *uint32   `protobuf:"varint,2,req,name=input" json:"input,omitempty"`
+	Name             *string   `protobuf:"bytes,3,req,name=name" json:"name,omitempty"`
+	Category         []string  `protobuf:"bytes,4,rep,name=category" json:"category,omitempty"`
+	Value            []float64 `protobuf:"fixed64,5,rep,name=value" json:"value,omitempty"`
+	Prior            *float64  `protobuf:"fixed64,6,opt,name=prior" json:"prior,omitempty"`
+	Smoothing        *float64  `protobuf:"fixed64,7,opt,name=smoothing" json:"smoothing,omitempty"`
+	NumFolds         *uint32   `protobuf:"varint,8,opt,name=num_folds" json:"num_folds,omitempty"`
+	XXX_unrecognized []byte    `json:"-"`
+}
+
+func (m *EncoderProto) Reset()         { *m = EncoderProto{} }
+func (m *EncoderProto) String() string { return proto.CompactTextString(m) }
+func (*EncoderProto) ProtoMessage()    {}
+
+func (m *EncoderProto) GetKind() string {
+	if m != nil && m.Kind != nil {
+		return *m.Kind
+	}
+	return ""
+}
+
+func (m *EncoderProto) GetInput() uint32 {
+	if m != nil && m.Input != nil {
+		return *m.Input
+	}
+	return 0
+}
+
+func (m *EncoderProto) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *EncoderProto) GetCategory() []string {
+	if m != nil {
+		return m.Category
+	}
+	return nil
+}
+
+func (m *EncoderProto) GetValue() []float64 {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *EncoderProto) GetPrior() float64 {
+	if m != nil && m.Prior != nil {
+		return *m.Prior
+	}
+	return 0
+}
+
+func (m *EncoderProto) GetSmoothing() float64 {
+	if m != nil && m.Smoothing != nil {
+		return *m.Smoothing
+	}
+	return 0
+}
+
+func (m *EncoderProto) GetNumFolds() uint32 {
+	if m != nil && m.NumFolds != nil {
+		return *m.NumFolds
+	}
+	return 0
+}
+
+type MultiClassAdaBoostProto struct {
+	NumStumps        *int32                  `protobuf:"varint,1,req,name=num_stumps" json:"num_stumps,omitempty"`
+	NumFeatures      *int32                  `protobuf:"varint,2,req,name=num_features" json:"num_features,omitempty"`
+	Stump            []*MultiClassStumpProto `protobuf:"bytes,3,rep,name=stump" json:"stump,omitempty"`
+	Mode             *string                 `protobuf:"bytes,4,opt,name=mode,def=samme" json:"mode,omitempty"`
+	Class            []int32                 `protobuf:"varint,5,rep,name=class" json:"class,omitempty"`
+	XXX_unrecognized []byte                  `json:"-"`
+}
+
+func (m *MultiClassAdaBoostProto) Reset()         { *m = MultiClassAdaBoostProto{} }
+func (m *MultiClassAdaBoostProto) String() string { return proto.CompactTextString(m) }
+func (*MultiClassAdaBoostProto) ProtoMessage()    {}
+
+const Default_MultiClassAdaBoostProto_Mode string = "samme"
+
+func (m *MultiClassAdaBoostProto) GetNumStumps() int32 {
+	if m != nil && m.NumStumps != nil {
+		return *m.NumStumps
+	}
+	return 0
+}
+
+func (m *MultiClassAdaBoostProto) GetNumFeatures() int32 {
+	if m != nil && m.NumFeatures != nil {
+		return *m.NumFeatures
+	}
+	return 0
+}
+
+func (m *MultiClassAdaBoostProto) GetStump() []*MultiClassStumpProto {
+	if m != nil {
+		return m.Stump
+	}
+	return nil
+}
+
+func (m *MultiClassAdaBoostProto) GetMode() string {
+	if m != nil && m.Mode != nil {
+		return *m.Mode
+	}
+	return Default_MultiClassAdaBoostProto_Mode
+}
+
+func (m *MultiClassAdaBoostProto) GetClass() []int32 {
+	if m != nil {
+		return m.Class
+	}
+	return nil
+}
+
+type MultiClassStumpProto struct {
+	FeatureNumber    *int32    `protobuf:"varint,1,req,name=feature_number" json:"feature_number,omitempty"`
+	Split            *float64  `protobuf:"fixed64,2,req,name=split" json:"split,omitempty"`
+	Weight           *float64  `protobuf:"fixed64,3,req,name=weight" json:"weight,omitempty"`
+	LeftProbability  []float64 `protobuf:"fixed64,4,rep,name=left_probability" json:"left_probability,omitempty"`
+	RightProbability []float64 `protobuf:"fixed64,5,rep,name=right_probability" json:"right_probability,omitempty"`
+	MissingAbove     *bool     `protobuf:"varint,6,opt,name=missing_above,def=0" json:"missing_above,omitempty"`
+	XXX_unrecognized []byte    `json:"-"`
+}
+
+func (m *MultiClassStumpProto) Reset()         { *m = MultiClassStumpProto{} }
+func (m *MultiClassStumpProto) String() string { return proto.CompactTextString(m) }
+func (*MultiClassStumpProto) ProtoMessage()    {}
+
+const Default_MultiClassStumpProto_MissingAbove bool = false
+
+func (m *MultiClassStumpProto) GetFeatureNumber() int32 {
+	if m != nil && m.FeatureNumber != nil {
+		return *m.FeatureNumber
+	}
+	return 0
+}
+
+func (m *MultiClassStumpProto) GetSplit() float64 {
+	if m != nil && m.Split != nil {
+		return *m.Split
+	}
+	return 0
+}
+
+func (m *MultiClassStumpProto) GetWeight() float64 {
+	if m != nil && m.Weight != nil {
+		return *m.Weight
+	}
+	return 0
+}
+
+func (m *MultiClassStumpProto) GetLeftProbability() []float64 {
+	if m != nil {
+		return m.LeftProbability
+	}
+	return nil
+}
+
+func (m *MultiClassStumpProto) GetRightProbability() []float64 {
+	if m != nil {
+		return m.RightProbability
+	}
+	return nil
+}
+
+func (m *MultiClassStumpProto) GetMissingAbove() bool {
+	if m != nil && m.MissingAbove != nil {
+		return *m.MissingAbove
+	}
+	return Default_MultiClassStumpProto_MissingAbove
+}
+
+type MultiLabelAdaBoostProto struct {
+	NumStumps        *int32                  `protobuf:"varint,1,req,name=num_stumps" json:"num_stumps,omitempty"`
+	NumFeatures      *int32                  `protobuf:"varint,2,req,name=num_features" json:"num_features,omitempty"`
+	Stump            []*MultiLabelStumpProto `protobuf:"bytes,3,rep,name=stump" json:"stump,omitempty"`
+	NumLabels        *int32                  `protobuf:"varint,4,req,name=num_labels" json:"num_labels,omitempty"`
+	XXX_unrecognized []byte                  `json:"-"`
+}
+
+func (m *MultiLabelAdaBoostProto) Reset()         { *m = MultiLabelAdaBoostProto{} }
+func (m *MultiLabelAdaBoostProto) String() string { return proto.CompactTextString(m) }
+func (*MultiLabelAdaBoostProto) ProtoMessage()    {}
+
+func (m *MultiLabelAdaBoostProto) GetNumStumps() int32 {
+	if m != nil && m.NumStumps != nil {
+		return *m.NumStumps
+	}
+	return 0
+}
+
+func (m *MultiLabelAdaBoostProto) GetNumFeatures() int32 {
+	if m != nil && m.NumFeatures != nil {
+		return *m.NumFeatures
+	}
+	return 0
+}
+
+func (m *MultiLabelAdaBoostProto) GetStump() []*MultiLabelStumpProto {
+	if m != nil {
+		return m.Stump
+	}
+	return nil
+}
+
+func (m *MultiLabelAdaBoostProto) GetNumLabels() int32 {
+	if m != nil && m.NumLabels != nil {
+		return *m.NumLabels
+	}
+	return 0
+}
+
+type MultiLabelStumpProto struct {
+	FeatureNumber    *int32   `protobuf:"varint,1,req,name=feature_number" json:"feature_number,omitempty"`
+	Split            *float64 `protobuf:"fixed64,2,req,name=split" json:"split,omitempty"`
+	Weight           *float64 `protobuf:"fixed64,3,req,name=weight" json:"weight,omitempty"`
+	Vote             []int32  `protobuf:"varint,4,rep,name=vote" json:"vote,omitempty"`
+	MissingAbove     *bool    `protobuf:"varint,5,opt,name=missing_above,def=0" json:"missing_above,omitempty"`
+	XXX_unrecognized []byte   `json:"-"`
+}
+
+func (m *MultiLabelStumpProto) Reset()         { *m = MultiLabelStumpProto{} }
+func (m *MultiLabelStumpProto) String() string { return proto.CompactTextString(m) }
+func (*MultiLabelStumpProto) ProtoMessage()    {}
+
+const Default_MultiLabelStumpProto_MissingAbove bool = false
+
+func (m *MultiLabelStumpProto) GetFeatureNumber() int32 {
+	if m != nil && m.FeatureNumber != nil {
+		return *m.FeatureNumber
+	}
+	return 0
+}
+
+func (m *MultiLabelStumpProto) GetSplit() float64 {
+	if m != nil && m.Split != nil {
+		return *m.Split
+	}
+	return 0
+}
+
+func (m *MultiLabelStumpProto) GetWeight() float64 {
+	if m != nil && m.Weight != nil {
+		return *m.Weight
+	}
+	return 0
+}
+
+func (m *MultiLabelStumpProto) GetVote() []int32 {
+	if m != nil {
+		return m.Vote
+	}
+	return nil
+}
+
+func (m *MultiLabelStumpProto) GetMissingAbove() bool {
+	if m != nil && m.MissingAbove != nil {
+		return *m.MissingAbove
+	}
+	return Default_MultiLabelStumpProto_MissingAbove
+}
+
+type RegressorAdaBoostProto struct {
+	NumRegressors    *int32            `protobuf:"varint,1,req,name=num_regressors" json:"num_regressors,omitempty"`
+	NumFeatures      *int32            `protobuf:"varint,2,req,name=num_features" json:"num_features,omitempty"`
+	Regressor        []*RegressorProto `protobuf:"bytes,3,rep,name=regressor" json:"regressor,omitempty"`
+	Loss             *string           `protobuf:"bytes,4,opt,name=loss,def=linear" json:"loss,omitempty"`
+	XXX_unrecognized []byte            `json:"-"`
+}
+
+func (m *RegressorAdaBoostProto) Reset()         { *m = RegressorAdaBoostProto{} }
+func (m *RegressorAdaBoostProto) String() string { return proto.CompactTextString(m) }
+func (*RegressorAdaBoostProto) ProtoMessage()    {}
+
+const Default_RegressorAdaBoostProto_Loss string = "linear"
+
+func (m *RegressorAdaBoostProto) GetNumRegressors() int32 {
+	if m != nil && m.NumRegressors != nil {
+		return *m.NumRegressors
+	}
+	return 0
+}
+
+func (m *RegressorAdaBoostProto) GetNumFeatures() int32 {
+	if m != nil && m.NumFeatures != nil {
+		return *m.NumFeatures
+	}
+	return 0
+}
+
+func (m *RegressorAdaBoostProto) GetRegressor() []*RegressorProto {
+	if m != nil {
+		return m.Regressor
+	}
+	return nil
+}
+
+func (m *RegressorAdaBoostProto) GetLoss() string {
+	if m != nil && m.Loss != nil {
+		return *m.Loss
+	}
+	return Default_RegressorAdaBoostProto_Loss
+}
+
+type RegressorProto struct {
+	MedianWeight     *float64             `protobuf:"fixed64,1,req,name=median_weight" json:"median_weight,omitempty"`
+	Tree             *RegressionTreeProto `protobuf:"bytes,2,opt,name=tree" json:"tree,omitempty"`
+	Stump            *StumpProto          `protobuf:"bytes,3,opt,name=stump" json:"stump,omitempty"`
+	XXX_unrecognized []byte               `json:"-"`
+}
+
+func (m *RegressorProto) Reset()         { *m = RegressorProto{} }
+func (m *RegressorProto) String() string { return proto.CompactTextString(m) }
+func (*RegressorProto) ProtoMessage()    {}
+
+func (m *RegressorProto) GetMedianWeight() float64 {
+	if m != nil && m.MedianWeight != nil {
+		return *m.MedianWeight
+	}
+	return 0
+}
+
+func (m *RegressorProto) GetTree() *RegressionTreeProto {
+	if m != nil {
+		return m.Tree
+	}
+	return nil
+}
+
+func (m *RegressorProto) GetStump() *StumpProto {
+	if m != nil {
+		return m.Stump
+	}
+	return nil
+}
+
+type RegressionTreeProto struct {
+	Weight           *float64             `protobuf:"fixed64,1,req,name=weight" json:"weight,omitempty"`
+	Root             *RegressionNodeProto `protobuf:"bytes,2,req,name=root" json:"root,omitempty"`
+	XXX_unrecognized []byte               `json:"-"`
+}
+
+func (m *RegressionTreeProto) Reset()         { *m = RegressionTreeProto{} }
+func (m *RegressionTreeProto) String() string { return proto.CompactTextString(m) }
+func (*RegressionTreeProto) ProtoMessage()    {}
+
+func (m *RegressionTreeProto) GetWeight() float64 {
+	if m != nil && m.Weight != nil {
+		return *m.Weight
+	}
+	return 0
+}
+
+func (m *RegressionTreeProto) GetRoot() *RegressionNodeProto {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+type RegressionNodeProto struct {
+	FeatureNumber    *int32               `protobuf:"varint,1,opt,name=feature_number" json:"feature_number,omitempty"`
+	Split            *float64             `protobuf:"fixed64,2,opt,name=split" json:"split,omitempty"`
+	Category         []float64            `protobuf:"fixed64,3,rep,name=category" json:"category,omitempty"`
+	MissingAbove     *bool                `protobuf:"varint,4,opt,name=missing_above,def=0" json:"missing_above,omitempty"`
+	Value            *float64             `protobuf:"fixed64,5,opt,name=value" json:"value,omitempty"`
+	Left             *RegressionNodeProto `protobuf:"bytes,6,opt,name=left" json:"left,omitempty"`
+	Right            *RegressionNodeProto `protobuf:"bytes,7,opt,name=right" json:"right,omitempty"`
+	XXX_unrecognized []byte               `json:"-"`
+}
+
+func (m *RegressionNodeProto) Reset()         { *m = RegressionNodeProto{} }
+func (m *RegressionNodeProto) String() string { return proto.CompactTextString(m) }
+func (*RegressionNodeProto) ProtoMessage()    {}
+
+const Default_RegressionNodeProto_MissingAbove bool = false
+
+func (m *RegressionNodeProto) GetFeatureNumber() int32 {
+	if m != nil && m.FeatureNumber != nil {
+		return *m.FeatureNumber
+	}
+	return 0
+}
+
+func (m *RegressionNodeProto) GetSplit() float64 {
+	if m != nil && m.Split != nil {
+		return *m.Split
+	}
+	return 0
+}
+
+func (m *RegressionNodeProto) GetCategory() []float64 {
+	if m != nil {
+		return m.Category
+	}
+	return nil
+}
+
+func (m *RegressionNodeProto) GetMissingAbove() bool {
+	if m != nil && m.MissingAbove != nil {
+		return *m.MissingAbove
+	}
+	return Default_RegressionNodeProto_MissingAbove
+}
+
+func (m *RegressionNodeProto) GetValue() float64 {
+	if m != nil && m.Value != nil {
+		return *m.Value
+	}
+	return 0
+}
+
+func (m *RegressionNodeProto) GetLeft() *RegressionNodeProto {
+	if m != nil {
+		return m.Left
+	}
+	return nil
+}
+
+func (m *RegressionNodeProto) GetRight() *RegressionNodeProto {
+	if m != nil {
+		return m.Right
+	}
+	return nil
+}
@@ -1,6 +1,9 @@
 package statistics
 
+// Positive and Negative count the binary classes, -1 being negative and anything else positive.
+// Classes counts every label, for multiclass samples.
 type ClassDistribution struct {
     Positive uint
     Negative uint
-}
\ No newline at end of file
+    Classes  map[int]uint
+}
@@ -0,0 +1,212 @@
+package statistics
+
+import (
+    "bytes"
+    "fmt"
+    "sort"
+)
+
+/**
+ * K×K confusion matrix for multiclass classifiers.
+ *
+ * matrix[i][j] = Samples of class i predicted as class j.
+ *
+ * Classes are integer labels, kept sorted. Labels not known when the matrix is created are added as they appear.
+ *
+ * @constructor
+ */
+type ConfusionMatrix struct {
+    classes []int
+    table   [][]uint
+}
+
+func NewConfusionMatrix(classes []int) ConfusionMatrix {
+    c := ConfusionMatrix{}
+    for _, class := range classes {
+        c.classToIndex(class)
+    }
+    return c
+}
+
+func (c *ConfusionMatrix) Classes() []int {
+    return c.classes
+}
+
+func (c *ConfusionMatrix) AddPrediction(y, h int) {
+    i := c.classToIndex(y)
+    j := c.classToIndex(h)
+    c.table[i][j]++
+}
+
+/**
+ * Number of samples of class y predicted as class h.
+ *
+ * @returns {number}
+ */
+func (c *ConfusionMatrix) Count(y, h int) uint {
+    i, iOk := c.findClass(y)
+    j, jOk := c.findClass(h)
+    if !iOk || !jOk {
+        return 0
+    }
+    return c.table[i][j]
+}
+
+func (c *ConfusionMatrix) TotalPopulation() (total uint) {
+    for _, row := range c.table {
+        for _, count := range row {
+            total += count
+        }
+    }
+    return
+}
+
+/**
+ * Samples correctly classified as the class.
+ *
+ * @returns {number}
+ */
+func (c *ConfusionMatrix) TruePositive(class int) uint {
+    return c.Count(class, class)
+}
+
+/**
+ * Samples of the class, Σ of the class row.
+ *
+ * @returns {number}
+ */
+func (c *ConfusionMatrix) ConditionPositive(class int) (total uint) {
+    if i, ok := c.findClass(class); ok {
+        for _, count := range c.table[i] {
+            total += count
+        }
+    }
+    return
+}
+
+/**
+ * Samples predicted as the class, Σ of the class column.
+ *
+ * @returns {number}
+ */
+func (c *ConfusionMatrix) PredictedConditionPositive(class int) (total uint) {
+    if j, ok := c.findClass(class); ok {
+        for _, row := range c.table {
+            total += row[j]
+        }
+    }
+    return
+}
+
+/**
+ * Accuracy (ACC) = Σ Diagonal / Σ Total population.
+ *
+ * @returns {number}
+ */
+func (c *ConfusionMatrix) Accuracy() float64 {
+    correct := uint(0)
+    for i := range c.table {
+        correct += c.table[i][i]
+    }
+    return float64(correct) / float64(c.TotalPopulation())
+}
+
+/**
+ * Precision of a class = True positive / Predicted condition positive.
+ *
+ * @returns {number}
+ */
+func (c *ConfusionMatrix) Precision(class int) float64 {
+    return float64(c.TruePositive(class)) / float64(c.PredictedConditionPositive(class))
+}
+
+/**
+ * Recall of a class = True positive / Condition positive.
+ *
+ * @returns {number}
+ */
+func (c *ConfusionMatrix) Recall(class int) float64 {
+    return float64(c.TruePositive(class)) / float64(c.ConditionPositive(class))
+}
+
+/**
+ * F1 score of a class, the harmonic mean of its precision and recall.
+ *
+ * @returns {number}
+ */
+func (c *ConfusionMatrix) F1Score(class int) float64 {
+    precision := c.Precision(class)
+    recall := c.Recall(class)
+    return 2 * precision * recall / (precision + recall)
+}
+
+/**
+ * Macro F1 score, the unweighted mean of the F1 score of each class.
+ *
+ * @returns {number}
+ */
+func (c *ConfusionMatrix) MacroF1Score() float64 {
+    sum := 0.0
+    for _, class := range c.classes {
+        sum += c.F1Score(class)
+    }
+    return sum / float64(len(c.classes))
+}
+
+/**
+ * To string.
+ *
+ * @returns {string}
+ */
+func (c *ConfusionMatrix) String() string {
+    var buffer bytes.Buffer
+    buffer.WriteString("\ny\\h")
+    for _, class := range c.classes {
+        buffer.WriteString(fmt.Sprintf("\t%d", class))
+    }
+    for i, class := range c.classes {
+        buffer.WriteString(fmt.Sprintf("\n%d", class))
+        for j := range c.classes {
+            buffer.WriteString(fmt.Sprintf("\t%d", c.table[i][j]))
+        }
+    }
+    buffer.WriteString(fmt.Sprintf("\nTotal population: %d\t", c.TotalPopulation()))
+    buffer.WriteString(fmt.Sprintf("\nAccuracy (ACC) = Σ Diagonal / Σ Total population: %f\t", c.Accuracy()))
+    for _, class := range c.classes {
+        buffer.WriteString(fmt.Sprintf("\nClass %d: precision: %f, recall: %f, F1: %f\t", class, c.Precision(class), c.Recall(class), c.F1Score(class)))
+    }
+    buffer.WriteString(fmt.Sprintf("\nMacro F1: %f\t", c.MacroF1Score()))
+    return buffer.String()
+}
+
+func (c *ConfusionMatrix) findClass(k int) (int, bool) {
+    i := sort.SearchInts(c.classes, k)
+    return i, i < len(c.classes) && c.classes[i] == k
+}
+
+/**
+ * Gets the index of the class in the matrix, growing the matrix when the class is new.
+ *
+ * @param k
+ * @returns {number}
+ */
+func (c *ConfusionMatrix) classToIndex(k int) int {
+    i, ok := c.findClass(k)
+    if ok {
+        return i
+    }
+    c.classes = append(c.classes, 0)
+    copy(c.classes[i + 1:], c.classes[i:])
+    c.classes[i] = k
+
+    // Inserts a row and a column at the new index.
+    for r := range c.table {
+        c.table[r] = append(c.table[r], 0)
+        copy(c.table[r][i + 1:], c.table[r][i:])
+        c.table[r][i] = 0
+    }
+    c.table = append(c.table, nil)
+    copy(c.table[i + 1:], c.table[i:])
+    c.table[i] = make([]uint, len(c.classes))
+    return i
+}
@@ -25,6 +25,18 @@ func NewContingencyTable() ContingencyTable {
     return ContingencyTable{}
 }
 
+/**
+ * Creates a table holding the given counts, as when restoring a saved evaluation.
+ */
+func NewContingencyTableFromCounts(truePositive, falsePositive, trueNegative, falseNegative uint) ContingencyTable {
+    c := ContingencyTable{}
+    c.table[1][1] = truePositive
+    c.table[0][1] = falsePositive
+    c.table[0][0] = trueNegative
+    c.table[1][0] = falseNegative
+    return c
+}
+
 func (c *ContingencyTable) TruePositive() uint {
     return c.table[1][1]
 }
@@ -2,14 +2,17 @@ package statistics
 
 import "math"
 
+// Statistics of a feature over the samples holding it. Missing values, NaN, are only counted by Missing.
 type FeatureStatistic struct {
-    Min float64
-    Max float64
-    Sum float64
-    Avg float64
-    Vrn float64
-    Std float64
-    Rng float64
+    Min     float64
+    Max     float64
+    Sum     float64
+    Avg     float64
+    Vrn     float64
+    Std     float64
+    Rng     float64
+    Count   uint
+    Missing uint
 }
 
 func NewFeatureStatistic() FeatureStatistic {
@@ -1,7 +1,7 @@
 package statistics
 
 import (
-    "log"
+    "github.com/dalmirdasilva/AdaBoostGo/utils"
     "math"
 )
 
@@ -12,18 +12,17 @@ func NewFeaturesAnalyzer() FeaturesAnalyzer {
     return FeaturesAnalyzer{}
 }
 
-func (f *FeaturesAnalyzer) Analyze(samples [][]float64) (statistics []FeatureStatistic, distribution ClassDistribution) {
-    numberOfSamples := len(samples)
-    if numberOfSamples < 1 {
-        log.Fatal("At least one sample is needed to analyze.")
+// Analyzes the samples, computing the statistics of each feature and the class distribution. The missing values of a
+// feature, NaN, are left out of its statistics.
+func (f *FeaturesAnalyzer) Analyze(samples [][]float64) (statistics []FeatureStatistic, distribution ClassDistribution, err error) {
+    if err = utils.ValidateSamples(samples, 0); err != nil {
+        return
     }
     var numberOfFeatures = len(samples[0])
-    if numberOfFeatures < 1 {
-        log.Fatal("At least feature is needed to analyze.")
-    }
     for i := 0; i < numberOfFeatures; i++ {
         statistics = append(statistics, NewFeatureStatistic())
     }
+    distribution.Classes = make(map[int]uint)
     for _, sample := range samples {
 
         // Find class distribution.
@@ -33,57 +32,139 @@ func (f *FeaturesAnalyzer) Analyze(samples [][]float64) (statistics []FeatureSta
         } else {
             distribution.Positive++
         }
+        distribution.Classes[int(y)]++
 
         // Find min and max
         for i := 0; i < numberOfFeatures; i++ {
-            statistic := &statistics[i]
-            featureValue := sample[i]
-            if featureValue < statistic.Min {
-                statistic.Min = featureValue
-            }
-            if featureValue > statistic.Max {
-                statistic.Max = featureValue
-            }
-            statistic.Sum += featureValue
+            observe(&statistics[i], sample[i])
         }
     }
 
     // Find avg abd rng
     for i, _ := range statistics {
-        statistic := &statistics[i]
-        statistic.Avg = statistic.Sum / float64(numberOfSamples)
-        statistic.Rng = math.Abs(statistic.Max - statistic.Min)
+        statistics[i].summarize()
     }
 
     // Find variance
     for _, sample := range samples {
         for i := 0; i < numberOfFeatures; i++ {
-            statistic := &statistics[i]
-            featureValue := sample[i]
-            statistic.Vrn += math.Pow(statistic.Avg - featureValue, 2)
+            if featureValue := sample[i]; !math.IsNaN(featureValue) {
+                statistics[i].Vrn += math.Pow(statistics[i].Avg - featureValue, 2)
+            }
         }
     }
 
     // Find std
     for i, _ := range statistics {
+        statistics[i].finish()
+    }
+    return
+}
+
+// Analyzes sparse samples as Analyze does with their dense form, the last statistic being the one of the labels.
+// Only the features other than 0 are visited.
+func (f *FeaturesAnalyzer) AnalyzeSparse(samples *utils.SparseSamples) (statistics []FeatureStatistic, distribution ClassDistribution, err error) {
+    if err = samples.Validate(); err != nil {
+        return
+    }
+    numberOfSamples := samples.NumberOfSamples()
+    numberOfFeatures := samples.NumberOfFeatures
+    statistics = make([]FeatureStatistic, numberOfFeatures + 1)
+    stored := make([]int, numberOfFeatures + 1)
+    for i := range statistics {
+        statistics[i] = NewFeatureStatistic()
+    }
+    distribution.Classes = make(map[int]uint)
+    for i, y := range samples.Labels {
+        if y == -1 {
+            distribution.Negative++
+        } else {
+            distribution.Positive++
+        }
+        distribution.Classes[int(y)]++
+        observe(&statistics[numberOfFeatures], y)
+        stored[numberOfFeatures]++
+        indices, values := samples.Row(i)
+        for j, index := range indices {
+            observe(&statistics[index], values[j])
+            stored[index]++
+        }
+    }
+
+    // The features not stored by every sample are 0 in the others.
+    for i := range statistics {
         statistic := &statistics[i]
-        statistic.Vrn /= float64(numberOfSamples - 1)
-        statistic.Std = math.Sqrt(statistic.Vrn)
+        zeros := numberOfSamples - stored[i]
+        if zeros > 0 {
+            observe(statistic, 0)
+            statistic.Count += uint(zeros - 1)
+        }
+        statistic.summarize()
+        statistic.Vrn = float64(zeros) * statistic.Avg * statistic.Avg
+    }
+    for i, y := range samples.Labels {
+        statistics[numberOfFeatures].Vrn += math.Pow(statistics[numberOfFeatures].Avg - y, 2)
+        indices, values := samples.Row(i)
+        for j, index := range indices {
+            if !math.IsNaN(values[j]) {
+                statistics[index].Vrn += math.Pow(statistics[index].Avg - values[j], 2)
+            }
+        }
+    }
+    for i := range statistics {
+        statistics[i].finish()
     }
     return
 }
 
+// Accounts a value of a feature, counting it as missing when it is NaN.
+func observe(statistic *FeatureStatistic, featureValue float64) {
+    if math.IsNaN(featureValue) {
+        statistic.Missing++
+        return
+    }
+    if featureValue < statistic.Min {
+        statistic.Min = featureValue
+    }
+    if featureValue > statistic.Max {
+        statistic.Max = featureValue
+    }
+    statistic.Sum += featureValue
+    statistic.Count++
+}
+
+// Computes the average and the range once all values were observed. They are NaN when every value is missing.
+func (s *FeatureStatistic) summarize() {
+    if s.Count < 1 {
+        s.Min, s.Max, s.Avg, s.Rng = math.NaN(), math.NaN(), math.NaN(), math.NaN()
+        return
+    }
+    s.Avg = s.Sum / float64(s.Count)
+    s.Rng = math.Abs(s.Max - s.Min)
+}
+
+// Computes the variance and the standard deviation once the squared deviations were summed into Vrn.
+func (s *FeatureStatistic) finish() {
+    s.Vrn /= float64(s.Count) - 1
+    s.Std = math.Sqrt(s.Vrn)
+}
+
 /**
- * Computes covariance and correlation.
+ * Computes covariance and correlation over the samples holding both variables.
  */
 func (f *FeaturesAnalyzer) Correlation(x, y uint, samples [][]float64, statistics []FeatureStatistic) VariableRelations {
     sum := 0.0
+    count := 0
     for _, sample := range samples {
         xValue := float64(sample[x])
         yValue := float64(sample[y])
+        if math.IsNaN(xValue) || math.IsNaN(yValue) {
+            continue
+        }
         sum += (xValue - statistics[x].Avg) * (yValue - statistics[y].Avg)
+        count++
     }
-    cov := sum / float64(len(samples) - 1)
+    cov := sum / float64(count - 1)
     cor := cov / (statistics[x].Std * statistics[y].Std)
     return VariableRelations{X: x, Y: y, Cov: cov, Cor: cor}
 }
@@ -0,0 +1,92 @@
+package statistics
+
+import (
+    "bytes"
+    "fmt"
+)
+
+/**
+ * Prediction statistics for multi-label classifiers.
+ *
+ * Keeps a binary contingency table per label, plus the mismatched (sample, label) pairs and the samples whose
+ * labels were all predicted right.
+ *
+ * @constructor
+ */
+type MultiLabelTable struct {
+    labels          []ContingencyTable
+    hammingErrors   uint
+    subsetMatches   uint
+    numberOfSamples uint
+}
+
+func NewMultiLabelTable(numberOfLabels uint) MultiLabelTable {
+    labels := make([]ContingencyTable, numberOfLabels)
+    for l := range labels {
+        labels[l] = NewContingencyTable()
+    }
+    return MultiLabelTable{labels: labels}
+}
+
+func (m *MultiLabelTable) AddPrediction(y, h []int) {
+    matches := true
+    for l := range m.labels {
+        m.labels[l].AddPrediction(y[l], h[l])
+        if (y[l] > 0) != (h[l] > 0) {
+            m.hammingErrors++
+            matches = false
+        }
+    }
+    if matches {
+        m.subsetMatches++
+    }
+    m.numberOfSamples++
+}
+
+func (m *MultiLabelTable) NumberOfLabels() int {
+    return len(m.labels)
+}
+
+/**
+ * Contingency table of a single label.
+ *
+ * @returns {ContingencyTable}
+ */
+func (m *MultiLabelTable) Label(l int) ContingencyTable {
+    return m.labels[l]
+}
+
+/**
+ * Hamming loss = Σ Mismatched (sample, label) pairs / (Σ Samples × L).
+ *
+ * @returns {number}
+ */
+func (m *MultiLabelTable) HammingLoss() float64 {
+    return float64(m.hammingErrors) / float64(m.numberOfSamples * uint(len(m.labels)))
+}
+
+/**
+ * Subset accuracy = Σ Samples with all labels right / Σ Samples.
+ *
+ * @returns {number}
+ */
+func (m *MultiLabelTable) SubsetAccuracy() float64 {
+    return float64(m.subsetMatches) / float64(m.numberOfSamples)
+}
+
+/**
+ * To string.
+ *
+ * @returns {string}
+ */
+func (m *MultiLabelTable) String() string {
+    var buffer bytes.Buffer
+    buffer.WriteString(fmt.Sprintf("\nTotal population: %d\t", m.numberOfSamples))
+    buffer.WriteString(fmt.Sprintf("\nHamming loss = Σ Mismatched pairs / Σ Total pairs: %f\t", m.HammingLoss()))
+    buffer.WriteString(fmt.Sprintf("\nSubset accuracy = Σ Exact matches / Σ Total population: %f\t", m.SubsetAccuracy()))
+    for l := range m.labels {
+        label := &m.labels[l]
+        buffer.WriteString(fmt.Sprintf("\nLabel %d: accuracy: %f, precision: %f, recall: %f\t", l, label.Accuracy(), label.Precision(), label.Recall()))
+    }
+    return buffer.String()
+}
@@ -0,0 +1,91 @@
+package statistics
+
+import (
+    "fmt"
+    "math"
+)
+
+/**
+ * Prediction errors of regressors.
+ *
+ * Keeps the sums needed to compute the errors and the coefficient of determination without storing the predictions.
+ *
+ * @constructor
+ */
+type RegressionErrors struct {
+    numberOfSamples    uint
+    sumOfAbsoluteError float64
+    sumOfSquaredError  float64
+    sumOfTargets       float64
+    sumOfSquaredTarget float64
+}
+
+func NewRegressionErrors() RegressionErrors {
+    return RegressionErrors{}
+}
+
+func (r *RegressionErrors) AddPrediction(y, h float64) {
+    r.numberOfSamples++
+    r.sumOfAbsoluteError += math.Abs(y - h)
+    r.sumOfSquaredError += (y - h) * (y - h)
+    r.sumOfTargets += y
+    r.sumOfSquaredTarget += y * y
+}
+
+func (r *RegressionErrors) TotalPopulation() uint {
+    return r.numberOfSamples
+}
+
+/**
+ * Mean absolute error (MAE) = Σ |y - h| / n.
+ *
+ * @returns {number}
+ */
+func (r *RegressionErrors) MeanAbsoluteError() float64 {
+    return r.sumOfAbsoluteError / float64(r.numberOfSamples)
+}
+
+/**
+ * Mean squared error (MSE) = Σ (y - h)² / n.
+ *
+ * @returns {number}
+ */
+func (r *RegressionErrors) MeanSquaredError() float64 {
+    return r.sumOfSquaredError / float64(r.numberOfSamples)
+}
+
+/**
+ * Root mean squared error (RMSE) = √MSE.
+ *
+ * @returns {number}
+ */
+func (r *RegressionErrors) RootMeanSquaredError() float64 {
+    return math.Sqrt(r.MeanSquaredError())
+}
+
+/**
+ * Coefficient of determination (R²) = 1 - Σ (y - h)² / Σ (y - ȳ)².
+ *
+ * @returns {number}
+ */
+func (r *RegressionErrors) RSquared() float64 {
+    n := float64(r.numberOfSamples)
+    totalSumOfSquares := r.sumOfSquaredTarget - r.sumOfTargets * r.sumOfTargets / n
+    return 1 - r.sumOfSquaredError / totalSumOfSquares
+}
+
+/**
+ * To string.
+ *
+ * @returns {string}
+ */
+func (r *RegressionErrors) String() string {
+    return fmt.Sprintf("\nTotal population: %d\t" +
+    "\nMean absolute error (MAE) = Σ |y - h| / n: %f\t" +
+    "\nRoot mean squared error (RMSE) = √(Σ (y - h)² / n): %f\t" +
+    "\nCoefficient of determination (R²) = 1 - Σ (y - h)² / Σ (y - ȳ)²: %f\t",
+        r.TotalPopulation(),
+        r.MeanAbsoluteError(),
+        r.RootMeanSquaredError(),
+        r.RSquared())
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+    "github.com/dalmirdasilva/AdaBoostGo/classifier"
+    "github.com/dalmirdasilva/AdaBoostGo/config"
+    "github.com/dalmirdasilva/AdaBoostGo/evaluation"
+    "github.com/dalmirdasilva/AdaBoostGo/io"
+    "github.com/dalmirdasilva/AdaBoostGo/preprocessing"
+    "math/rand"
+    "sort"
+    "errors"
+    "time"
+    "fmt"
+)
+
+// Trains a model on a labelled file, holding out the first test percent of the samples for its evaluation and the
+// next validation percent to stop early on.
+func runTrain(args []string) error {
+    options := classifier.DefaultOptions()
+    flagSet := newFlagSet("train", "data.csv|data.libsvm")
+    optionsFilePath := flagSet.String("options", "", "JSON file holding the options, overridden by the flags")
+    modelFilePath := flagSet.String("model", "", "file to write the model to")
+    format := flagSet.String("format", "", "model format: "+MODEL_FORMAT_USAGE)
+    shuffle := flagSet.Bool("shuffle", false, "shuffle the samples before holding out the test set")
+    seed := flagSet.Int64("seed", 0, "seed of the random numbers, 0 uses the current time")
+    categorical := flagSet.String("categorical", "", "comma separated features holding integer-coded categories, by name or number, split into subsets")
+    oneHot := flagSet.String("one-hot", "", "comma separated indexes of the category columns of a CSV file to encode into one feature per category")
+    ordinal := flagSet.String("ordinal", "", "comma separated indexes of the category columns of a CSV file to encode into category numbers, split into subsets")
+    targetEncode := flagSet.String("target-encode", "", "comma separated indexes of the category columns of a CSV file to encode into their smoothed mean label")
+    targetSmoothing := flagSet.Float64("target-smoothing", preprocessing.DEFAULT_SMOOTHING, "number of samples of the mean label the target encodings are smoothed towards")
+    targetFolds := flagSet.Int("target-folds", preprocessing.DEFAULT_NUMBER_OF_FOLDS, "number of folds the training samples are target encoded out of, none when less than 2")
+    config.BindFlags(flagSet, &options)
+    datasetFlags := bindDatasetFlags(flagSet)
+    if err := parseArguments(flagSet, args, 1); err != nil {
+        return err
+    }
+    if *optionsFilePath != "" {
+        loaded, err := config.LoadOptions(*optionsFilePath)
+        if err != nil {
+            return err
+        }
+        options = loaded
+        flagSet.Parse(args)
+    }
+    if err := options.Validate(); err != nil {
+        return err
+    }
+    if *modelFilePath == "" {
+        return errors.New("the -model flag is needed")
+    }
+    if *seed != 0 {
+        rand.Seed(*seed)
+    }
+
+    // The encoders draw from their own source, so the seed gives the same encoding whatever else draws numbers.
+    encoderSeed := *seed
+    if encoderSeed == 0 {
+        encoderSeed = time.Now().UTC().UnixNano()
+    }
+
+    datasetOptions, err := datasetFlags.options()
+    if err != nil {
+        return err
+    }
+    encoderKinds := map[int]string{}
+    for kind, value := range map[string]string{preprocessing.ONE_HOT_ENCODER: *oneHot, preprocessing.ORDINAL_ENCODER: *ordinal, preprocessing.TARGET_ENCODER: *targetEncode} {
+        columns, err := parseColumns(value)
+        if err != nil {
+            return err
+        }
+        for column := range columns {
+            if _, ok := encoderKinds[column]; ok {
+                return fmt.Errorf("column %d has more than one encoder", column)
+            }
+            encoderKinds[column] = kind
+            datasetOptions.CategoryColumns = append(datasetOptions.CategoryColumns, column)
+        }
+    }
+
+    // The dataset holds the category columns in the order of the file.
+    sort.Ints(datasetOptions.CategoryColumns)
+    encoders := []preprocessing.Encoder{}
+    for _, column := range datasetOptions.CategoryColumns {
+        encoder, err := preprocessing.NewEncoder(encoderKinds[column])
+        if err != nil {
+            return err
+        }
+        if targetEncoder, ok := encoder.(*preprocessing.TargetEncoder); ok {
+            targetEncoder.Smoothing = *targetSmoothing
+            targetEncoder.NumberOfFolds = *targetFolds
+        }
+        encoders = append(encoders, encoder)
+    }
+    samples, err := readDataset(flagSet.Arg(0), 0, datasetOptions)
+    if err != nil {
+        return err
+    }
+    if samples.summary != nil {
+        fmt.Println(samples.summary.String())
+    }
+    if err := samples.validate(); err != nil {
+        return err
+    }
+    if *shuffle {
+        samples.shuffle()
+    }
+    testSize := int(options.TestPercent * float64(samples.size()))
+    testSamples, trainingSamples := samples.split(testSize)
+    validationSize := int(options.ValidationPercent * float64(samples.size()))
+    validationSamples, trainingSamples := trainingSamples.split(validationSize)
+    if options.EarlyStoppingRounds > 0 && validationSamples.size() == 0 {
+        return errors.New("early stopping needs validation samples, held out by the -validation-percent flag")
+    }
+
+    // The encoders are fitted on the training samples only, so the test samples are encoded as new ones would be.
+    var pipeline *preprocessing.Pipeline
+    if len(encoders) > 0 {
+        if pipeline, trainingSamples, err = trainingSamples.fitPipeline(encoders, rand.New(rand.NewSource(encoderSeed))); err != nil {
+            return err
+        }
+        if testSamples, err = testSamples.transform(pipeline); err != nil {
+            return err
+        }
+        if validationSamples, err = validationSamples.transform(pipeline); err != nil {
+            return err
+        }
+    }
+    numberOfFeatures := trainingSamples.numberOfFeatures()
+    if *categorical != "" {
+        if options.CategoricalFeatures, err = trainingSamples.parseFeatures(*categorical); err != nil {
+            return err
+        }
+    }
+    if pipeline != nil {
+        options.CategoricalFeatures = append(options.CategoricalFeatures, pipeline.CategoricalFeatures()...)
+    }
+    for _, featureNumber := range options.CategoricalFeatures {
+        if featureNumber >= numberOfFeatures {
+            return fmt.Errorf("categorical feature %d is out of the %d features", featureNumber, numberOfFeatures)
+        }
+    }
+
+    adaBoost := classifier.NewAdaBoost(options)
+    if validationSamples.size() > 0 {
+        if err := validationSamples.setValidationSet(&adaBoost); err != nil {
+            return err
+        }
+    }
+    if err := trainingSamples.train(&adaBoost); err != nil {
+        return err
+    }
+    model := io.NewModel(adaBoost, numberOfFeatures)
+    model.FeatureNames = trainingSamples.featureNames()
+    model.Pipeline = pipeline
+    if model.ClassDistribution, err = trainingSamples.classDistribution(); err != nil {
+        return err
+    }
+
+    trainingErrors := adaBoost.GetTrainingErrors()
+    fmt.Printf("Trained %d weak classifiers on %d samples with %d features.\n", len(adaBoost.WeakClassifiers), trainingSamples.size(), numberOfFeatures)
+    if len(trainingErrors) > 0 {
+        fmt.Printf("Training error: %f\n", trainingErrors[len(trainingErrors) - 1])
+    }
+    if validationMetrics := adaBoost.GetValidationMetrics(); len(validationMetrics) > 0 {
+        bestRound := len(adaBoost.WeakClassifiers)
+        fmt.Printf("Stopped at round %d of %d, keeping the %d rounds up to the best validation %s over %d samples: %f\n",
+            adaBoost.GetStoppedRound(), options.NumberOfClassifiers, bestRound, options.EarlyStoppingMetric, validationSamples.size(), validationMetrics[bestRound - 1])
+    }
+
+    if testSamples.size() > 0 {
+        evaluator := evaluation.NewEvaluator(&adaBoost, options)
+        contingencyTable, err := testSamples.evaluate(&evaluator)
+        if err != nil {
+            return err
+        }
+        model.Evaluation = &contingencyTable
+        fmt.Printf("Evaluation over %d test samples:\n%s\n", testSamples.size(), contingencyTable.String())
+    }
+
+    return saveModel(*modelFilePath, *format, model)
+}
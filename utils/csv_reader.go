@@ -0,0 +1,506 @@
+package utils
+
+import (
+    "os"
+    "encoding/csv"
+    "bufio"
+    "strings"
+    "strconv"
+    "errors"
+    "math"
+    "io"
+    "fmt"
+)
+
+// Tells whether the first row of a CSV file holds the column names.
+type HeaderPolicy int
+
+const (
+
+    // The first row is a header when any of its feature, label or weight cells is not a number nor a missing value.
+    DETECT_HEADER HeaderPolicy = iota
+    HEADER_PRESENT
+    HEADER_ABSENT
+)
+
+// Tells what to do with the rows having a different number of columns than the first one.
+type RaggedRowPolicy int
+
+const (
+    REJECT_RAGGED_ROWS RaggedRowPolicy = iota
+
+    // Shorter rows are completed with missing values. Longer rows are rejected.
+    PAD_RAGGED_ROWS
+
+    // Ragged rows are dropped, and counted by the load summary.
+    SKIP_RAGGED_ROWS
+)
+
+// Tells how the label column of a CSV file is read.
+type LabelPolicy int
+
+const (
+    READ_LABEL LabelPolicy = iota
+
+    // The label column is neither a feature nor read, as the placeholders of a file to classify.
+    SKIP_LABEL
+
+    // The file has no label column.
+    NO_LABEL
+)
+
+// Reading stops after finding this many errors.
+const MAX_ROW_ERRORS = 100
+
+// Tells how to read a CSV file and how its columns make a dataset. Column indexes start at 0, and a negative index
+// means the column is absent, except for LabelColumn, where it means the last column.
+type DatasetOptions struct {
+    Header    HeaderPolicy
+    Delimiter rune
+
+    // Cells holding one of these values, after trimming their spaces, are missing. Missing features are read as NaN.
+    // Labels and weights cannot be missing.
+    MissingValues []string
+
+    RaggedRows RaggedRowPolicy
+
+    Label       LabelPolicy
+    LabelColumn int
+
+    // Column holding the sample IDs, kept as text.
+    IDColumn int
+
+    // Column holding a non negative weight for each sample.
+    WeightColumn int
+
+    // Columns that are neither features nor read.
+    IgnoredColumns []int
+
+    // Columns holding categories, kept as text for a preprocessing pipeline to encode.
+    CategoryColumns []int
+
+    // Category columns given by their position among the input columns, which are the feature and category columns
+    // in the order of the file, as a pipeline records them.
+    CategoryInputs []int
+}
+
+func DefaultDatasetOptions() DatasetOptions {
+    return DatasetOptions{Header: DETECT_HEADER, Delimiter: ',', RaggedRows: REJECT_RAGGED_ROWS, LabelColumn: -1, IDColumn: -1, WeightColumn: -1}
+}
+
+// What was loaded from a CSV file.
+type LoadSummary struct {
+
+    // Rows of the file, the header included.
+    Rows          int
+    HasHeader     bool
+    Samples       int
+    Features      int
+    Categories    int
+    SkippedRows   int
+    PaddedRows    int
+    MissingValues int
+}
+
+func (s LoadSummary) String() string {
+    header := "no header"
+    if s.HasHeader {
+        header = "a header"
+    }
+    categories := ""
+    if s.Categories > 0 {
+        categories = fmt.Sprintf(" and %d category columns", s.Categories)
+    }
+    return fmt.Sprintf("Loaded %d samples with %d features%s from %d rows with %s: %d skipped rows, %d padded rows, %d missing values.",
+        s.Samples, s.Features, categories, s.Rows, header, s.SkippedRows, s.PaddedRows, s.MissingValues)
+}
+
+// Errors found reading a file, one per bad cell or row, in the order they were found.
+type RowErrors []*RowError
+
+func (e RowErrors) Error() string {
+    messages := []string{}
+    for i, err := range e {
+        if i == 10 {
+            messages = append(messages, fmt.Sprintf("and %d more", len(e) - i))
+            break
+        }
+        messages = append(messages, err.Error())
+    }
+    if len(e) == 1 {
+        return messages[0]
+    }
+    return fmt.Sprintf("%d errors: %s", len(e), strings.Join(messages, "; "))
+}
+
+func (e RowErrors) Unwrap() []error {
+    errs := make([]error, len(e))
+    for i, err := range e {
+        errs[i] = err
+    }
+    return errs
+}
+
+// Reads a dataset from a CSV file, all values but the IDs, the categories, the ignored columns and the missing values
+// being numbers.
+// Every bad cell is reported by the returned RowErrors, up to MAX_ROW_ERRORS.
+func ReadDataset(fileName string, options DatasetOptions) (*Dataset, LoadSummary, error) {
+    f, err := os.Open(fileName)
+    if err != nil {
+        return nil, LoadSummary{}, NewIOError("open", fileName, err)
+    }
+    defer f.Close()
+    r := csv.NewReader(bufio.NewReader(f))
+    r.Comma = options.Delimiter
+    r.FieldsPerRecord = -1
+    reader := newDatasetReader(options)
+    for row := 0; len(reader.errors) < MAX_ROW_ERRORS; row++ {
+        record, err := r.Read()
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return nil, reader.summary, NewIOError("read", fileName, err)
+        }
+        if err := reader.read(record, row); err != nil {
+            return nil, reader.summary, err
+        }
+    }
+    if len(reader.errors) > 0 {
+        return nil, reader.summary, reader.errors
+    }
+    if len(reader.dataset.Samples) < 1 {
+        return nil, reader.summary, ErrEmptyDataset
+    }
+    return reader.dataset, reader.summary, nil
+}
+
+// A record of a CSV file once parsed. The label and the weight are 0 when the file has none.
+type Record struct {
+
+    // Features, missing ones being NaN, and categories, missing ones being empty, in the order of the file.
+    Features   []float64
+    Categories []string
+
+    Label  float64
+    Weight float64
+    ID     string
+}
+
+// Parses the records of a CSV file one at a time, as ReadDataset does, so files of any size can be streamed.
+type RecordParser struct {
+    reader *datasetReader
+}
+
+func NewRecordParser(options DatasetOptions) *RecordParser {
+    return &RecordParser{reader: newDatasetReader(options)}
+}
+
+// Parses a record, the first one being row 0. The record is nil for the header and the skipped rows. Bad options and
+// the RowErrors of bad rows are returned.
+func (p *RecordParser) Parse(record []string, row int) (*Record, error) {
+    parsed, err := p.reader.parse(record, row)
+    if len(p.reader.errors) > 0 {
+        err = p.reader.errors
+        p.reader.errors = nil
+    }
+    return parsed, err
+}
+
+// Gets the summary of the records parsed so far, which tells if the first one was a header.
+func (p *RecordParser) Summary() LoadSummary {
+    return p.reader.summary
+}
+
+// Role of a column of a CSV file in a dataset.
+type columnRole int
+
+const (
+    featureColumn columnRole = iota
+    labelColumn
+    idColumn
+    weightColumn
+    ignoredColumn
+    categoryColumn
+)
+
+// Builds a dataset from the records of a CSV file.
+type datasetReader struct {
+    options       DatasetOptions
+    missingValues map[string]bool
+    roles         []columnRole
+    dataset       *Dataset
+    summary       LoadSummary
+    errors        RowErrors
+}
+
+func newDatasetReader(options DatasetOptions) *datasetReader {
+    missingValues := map[string]bool{}
+    for _, value := range options.MissingValues {
+        missingValues[strings.TrimSpace(value)] = true
+    }
+    return &datasetReader{options: options, missingValues: missingValues, dataset: &Dataset{}}
+}
+
+// Reads a record. Bad cells and rows are collected, only bad options are returned.
+func (r *datasetReader) read(record []string, row int) error {
+    parsed, err := r.parse(record, row)
+    if err != nil || parsed == nil {
+        return err
+    }
+    d := r.dataset
+    d.Samples = append(d.Samples, append(parsed.Features, parsed.Label))
+    if len(d.CategoryInputs) > 0 {
+        d.Categories = append(d.Categories, parsed.Categories)
+    }
+    if r.options.IDColumn >= 0 {
+        d.IDs = append(d.IDs, parsed.ID)
+    }
+    if r.options.WeightColumn >= 0 {
+        d.Weights = append(d.Weights, parsed.Weight)
+    }
+    return nil
+}
+
+// Parses a record, getting nil for the header, the skipped rows and the rows having bad cells, which are collected.
+// Only bad options are returned.
+func (r *datasetReader) parse(record []string, row int) (*Record, error) {
+    r.summary.Rows++
+    if r.roles == nil {
+        var err error
+        if r.roles, err = r.options.columnRoles(len(record)); err != nil {
+            return nil, err
+        }
+        r.summary.Features = r.countRole(featureColumn)
+        r.summary.Categories = r.countRole(categoryColumn)
+        input := 0
+        for _, role := range r.roles {
+            if role == categoryColumn {
+                r.dataset.CategoryInputs = append(r.dataset.CategoryInputs, input)
+            }
+            if role == featureColumn || role == categoryColumn {
+                input++
+            }
+        }
+        if r.isHeader(record) {
+            r.summary.HasHeader = true
+            for column, name := range record {
+                switch r.roles[column] {
+                case featureColumn:
+                    r.dataset.FeatureNames = append(r.dataset.FeatureNames, strings.TrimSpace(name))
+                case categoryColumn:
+                    r.dataset.CategoryNames = append(r.dataset.CategoryNames, strings.TrimSpace(name))
+                }
+            }
+            return nil, nil
+        }
+    }
+    if len(record) != len(r.roles) {
+        switch {
+        case r.options.RaggedRows == SKIP_RAGGED_ROWS:
+            r.summary.SkippedRows++
+            return nil, nil
+        case r.options.RaggedRows == PAD_RAGGED_ROWS && len(record) < len(r.roles):
+            r.summary.PaddedRows++
+        default:
+            r.errors = append(r.errors, &RowError{Row: row, Column: -1, Err: fmt.Errorf("%w: %d columns instead of %d", ErrRaggedRow, len(record), len(r.roles))})
+            return nil, nil
+        }
+    }
+    parsed := r.parseRecord(record, row)
+    if parsed != nil {
+        r.summary.Samples++
+    }
+    return parsed, nil
+}
+
+func (r *datasetReader) countRole(role columnRole) int {
+    count := 0
+    for _, columnRole := range r.roles {
+        if columnRole == role {
+            count++
+        }
+    }
+    return count
+}
+
+// Tells if the first record is a header, according to the policy.
+func (r *datasetReader) isHeader(record []string) bool {
+    switch r.options.Header {
+    case HEADER_PRESENT:
+        return true
+    case HEADER_ABSENT:
+        return false
+    }
+    for column, role := range r.roles {
+        if role != featureColumn && role != labelColumn && role != weightColumn {
+            continue
+        }
+        value := strings.TrimSpace(record[column])
+        if _, err := strconv.ParseFloat(value, 64); err != nil && !r.missingValues[value] {
+            return true
+        }
+    }
+    return false
+}
+
+// Parses the cells of a record. Cells missing from a padded record are missing values, which are empty for the
+// categories. Records having bad cells are nil.
+func (r *datasetReader) parseRecord(record []string, row int) *Record {
+    numberOfErrors := len(r.errors)
+    parse := func(column int, canBeMissing bool) float64 {
+        value := ""
+        missing := column >= len(record)
+        if !missing {
+            value = strings.TrimSpace(record[column])
+            missing = r.missingValues[value]
+        }
+        if missing && canBeMissing {
+            r.summary.MissingValues++
+            return math.NaN()
+        }
+        if missing {
+            r.errors = append(r.errors, &RowError{Row: row, Column: column, Err: fmt.Errorf("%w: missing", ErrInvalidValue)})
+            return 0
+        }
+        number, err := strconv.ParseFloat(value, 64)
+        if err != nil {
+            r.errors = append(r.errors, &RowError{Row: row, Column: column, Err: fmt.Errorf("%w: %q", ErrInvalidValue, value)})
+        }
+        return number
+    }
+    parsed := &Record{Features: make([]float64, 0, len(r.roles))}
+    for column, role := range r.roles {
+        switch role {
+        case featureColumn:
+            parsed.Features = append(parsed.Features, parse(column, true))
+        case categoryColumn:
+            category := ""
+            if column < len(record) && !r.missingValues[strings.TrimSpace(record[column])] {
+                category = strings.TrimSpace(record[column])
+            } else {
+                r.summary.MissingValues++
+            }
+            parsed.Categories = append(parsed.Categories, category)
+        case labelColumn:
+            parsed.Label = parse(column, false)
+        case idColumn:
+            if column < len(record) {
+                parsed.ID = record[column]
+            }
+        case weightColumn:
+            if parsed.Weight = parse(column, false); parsed.Weight < 0 {
+                r.errors = append(r.errors, &RowError{Row: row, Column: column, Err: fmt.Errorf("%w: negative weight %v", ErrInvalidValue, parsed.Weight)})
+            }
+        }
+    }
+    if len(r.errors) > numberOfErrors {
+        return nil
+    }
+    return parsed
+}
+
+// Gets the role of each column of a file with the given number of columns.
+func (o DatasetOptions) columnRoles(numberOfColumns int) ([]columnRole, error) {
+    roles := make([]columnRole, numberOfColumns)
+    label := o.LabelColumn
+    if label < 0 {
+        label = numberOfColumns - 1
+    }
+    assign := func(column int, role columnRole) error {
+        if column >= numberOfColumns {
+            return fmt.Errorf("%w: column %d is out of the %d columns", ErrInvalidColumn, column, numberOfColumns)
+        }
+        if roles[column] != featureColumn {
+            return fmt.Errorf("%w: column %d has more than one role", ErrInvalidColumn, column)
+        }
+        roles[column] = role
+        return nil
+    }
+    switch o.Label {
+    case READ_LABEL:
+        if err := assign(label, labelColumn); err != nil {
+            return nil, err
+        }
+    case SKIP_LABEL:
+        if err := assign(label, ignoredColumn); err != nil {
+            return nil, err
+        }
+    }
+    if o.IDColumn >= 0 {
+        if err := assign(o.IDColumn, idColumn); err != nil {
+            return nil, err
+        }
+    }
+    if o.WeightColumn >= 0 {
+        if err := assign(o.WeightColumn, weightColumn); err != nil {
+            return nil, err
+        }
+    }
+    for _, column := range o.IgnoredColumns {
+        if column < 0 {
+            return nil, fmt.Errorf("%w: column %d", ErrInvalidColumn, column)
+        }
+        if err := assign(column, ignoredColumn); err != nil {
+            return nil, err
+        }
+    }
+    for _, column := range o.CategoryColumns {
+        if column < 0 {
+            return nil, fmt.Errorf("%w: column %d", ErrInvalidColumn, column)
+        }
+        if err := assign(column, categoryColumn); err != nil {
+            return nil, err
+        }
+    }
+    if len(o.CategoryInputs) == 0 {
+        return roles, nil
+    }
+    categoryInputs := map[int]bool{}
+    for _, input := range o.CategoryInputs {
+        categoryInputs[input] = true
+    }
+    numberOfInputs := 0
+    for column, role := range roles {
+        if role != featureColumn && role != categoryColumn {
+            continue
+        }
+        if categoryInputs[numberOfInputs] {
+            if err := assign(column, categoryColumn); err != nil {
+                return nil, err
+            }
+            delete(categoryInputs, numberOfInputs)
+        }
+        numberOfInputs++
+    }
+    for input := range categoryInputs {
+        return nil, fmt.Errorf("%w: input %d is out of the %d inputs", ErrInvalidColumn, input, numberOfInputs)
+    }
+    return roles, nil
+}
+
+// Parses the name of a header policy: detect, yes or no.
+func ParseHeaderPolicy(name string) (HeaderPolicy, error) {
+    switch name {
+    case "detect":
+        return DETECT_HEADER, nil
+    case "yes":
+        return HEADER_PRESENT, nil
+    case "no":
+        return HEADER_ABSENT, nil
+    }
+    return DETECT_HEADER, errors.New("unknown header policy " + name)
+}
+
+// Parses the name of a ragged row policy: reject, pad or skip.
+func ParseRaggedRowPolicy(name string) (RaggedRowPolicy, error) {
+    switch name {
+    case "reject":
+        return REJECT_RAGGED_ROWS, nil
+    case "pad":
+        return PAD_RAGGED_ROWS, nil
+    case "skip":
+        return SKIP_RAGGED_ROWS, nil
+    }
+    return REJECT_RAGGED_ROWS, errors.New("unknown ragged row policy " + name)
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+    "strconv"
+    "fmt"
+)
+
+// Samples with their metadata.
+//
+// Samples hold the features followed by the label, in the last position, whatever the column of the label in the
+// file. IDs and Weights are nil when the file has no such columns.
+//
+// The category columns, read as text, are kept out of the samples until a preprocessing pipeline encodes them.
+// Categories holds the categories of each sample, an empty one being missing, and is nil when there are no category
+// columns.
+type Dataset struct {
+    FeatureNames []string
+    Samples      [][]float64
+    IDs          []string
+    Weights      []float64
+
+    CategoryNames []string
+    Categories    [][]string
+
+    // Position of each category column among the input columns, which are the feature and category columns in the
+    // order of the file.
+    CategoryInputs []int
+}
+
+// Creates a dataset of samples having the label in the last position, without metadata.
+func NewDataset(samples [][]float64) *Dataset {
+    return &Dataset{Samples: samples}
+}
+
+func (d *Dataset) NumberOfSamples() int {
+    return len(d.Samples)
+}
+
+// Gets the number of features, which is 0 for an empty dataset.
+func (d *Dataset) NumberOfFeatures() int {
+    if len(d.Samples) < 1 {
+        return 0
+    }
+    return len(d.Samples[0]) - 1
+}
+
+// Gets the name of a feature, or its number when the dataset has no names.
+func (d *Dataset) FeatureName(featureNumber uint) string {
+    if int(featureNumber) < len(d.FeatureNames) {
+        return d.FeatureNames[featureNumber]
+    }
+    return strconv.Itoa(int(featureNumber))
+}
+
+// Gets a new dataset holding the given samples, in the given order, with their IDs, weights and categories.
+func (d *Dataset) Select(rows []int) *Dataset {
+    selected := &Dataset{FeatureNames: d.FeatureNames, Samples: make([][]float64, len(rows)), CategoryNames: d.CategoryNames, CategoryInputs: d.CategoryInputs}
+    if d.IDs != nil {
+        selected.IDs = make([]string, len(rows))
+    }
+    if d.Weights != nil {
+        selected.Weights = make([]float64, len(rows))
+    }
+    if d.Categories != nil {
+        selected.Categories = make([][]string, len(rows))
+    }
+    for i, row := range rows {
+        selected.Samples[i] = d.Samples[row]
+        if d.IDs != nil {
+            selected.IDs[i] = d.IDs[row]
+        }
+        if d.Weights != nil {
+            selected.Weights[i] = d.Weights[row]
+        }
+        if d.Categories != nil {
+            selected.Categories[i] = d.Categories[row]
+        }
+    }
+    return selected
+}
+
+// Splits the dataset into the first n samples and the rest.
+func (d *Dataset) Split(n int) (*Dataset, *Dataset) {
+    head := make([]int, n)
+    tail := make([]int, d.NumberOfSamples() - n)
+    for i := range head {
+        head[i] = i
+    }
+    for i := range tail {
+        tail[i] = n + i
+    }
+    return d.Select(head), d.Select(tail)
+}
+
+// Checks the samples as ValidateSamples does, a dataset with category columns needing no features, that the metadata
+// has one entry per sample and that the weights are non negative, not all 0.
+func (d *Dataset) Validate() error {
+    numberOfLabels := 1
+    if len(d.CategoryInputs) > 0 {
+        numberOfLabels = 0
+    }
+    if err := ValidateSamples(d.Samples, numberOfLabels); err != nil {
+        return err
+    }
+    if d.FeatureNames != nil && len(d.FeatureNames) != d.NumberOfFeatures() {
+        return fmt.Errorf("%w: %d feature names for %d features", ErrInvalidColumn, len(d.FeatureNames), d.NumberOfFeatures())
+    }
+    if d.IDs != nil && len(d.IDs) != d.NumberOfSamples() {
+        return fmt.Errorf("%w: %d IDs for %d samples", ErrInvalidColumn, len(d.IDs), d.NumberOfSamples())
+    }
+    if d.Weights != nil && len(d.Weights) != d.NumberOfSamples() {
+        return fmt.Errorf("%w: %d weights for %d samples", ErrInvalidColumn, len(d.Weights), d.NumberOfSamples())
+    }
+    if d.CategoryNames != nil && len(d.CategoryNames) != len(d.CategoryInputs) {
+        return fmt.Errorf("%w: %d category names for %d category columns", ErrInvalidColumn, len(d.CategoryNames), len(d.CategoryInputs))
+    }
+    if len(d.CategoryInputs) > 0 && len(d.Categories) != d.NumberOfSamples() {
+        return fmt.Errorf("%w: %d category rows for %d samples", ErrInvalidColumn, len(d.Categories), d.NumberOfSamples())
+    }
+    for i, categories := range d.Categories {
+        if len(categories) != len(d.CategoryInputs) {
+            return &RowError{Row: i, Column: -1, Err: fmt.Errorf("%w: %d categories instead of %d", ErrRaggedRow, len(categories), len(d.CategoryInputs))}
+        }
+    }
+    totalWeight := 0.0
+    for i, weight := range d.Weights {
+        if weight < 0 {
+            return &RowError{Row: i, Column: -1, Err: fmt.Errorf("%w: negative weight %v", ErrInvalidValue, weight)}
+        }
+        totalWeight += weight
+    }
+    if d.Weights != nil && totalWeight <= 0 {
+        return fmt.Errorf("%w: the weights sum to 0", ErrInvalidValue)
+    }
+    return nil
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+    "errors"
+    "fmt"
+    "io/fs"
+)
+
+// Errors returned by the library. Callers can check them with errors.Is, as they are usually wrapped by a RowError
+// or an IOError.
+var (
+    ErrEmptyDataset = errors.New("at least one sample is needed")
+    ErrNoFeatures = errors.New("at least one feature is needed")
+    ErrRaggedRow = errors.New("row has a different number of columns than the first one")
+    ErrInvalidValue = errors.New("value is not a number")
+    ErrInvalidLabel = errors.New("invalid label")
+    ErrInvalidFeatureIndex = errors.New("invalid feature index")
+    ErrInvalidColumn = errors.New("invalid column")
+    ErrDegenerateWeakClassifier = errors.New("no weak classifier can be generated")
+)
+
+// Error found at a row of a dataset, and at a column of it when Column is not negative.
+type RowError struct {
+    Row    int
+    Column int
+    Err    error
+}
+
+func (e *RowError) Error() string {
+    if e.Column < 0 {
+        return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+    }
+    return fmt.Sprintf("row %d, column %d: %v", e.Row, e.Column, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+    return e.Err
+}
+
+// Error reading or writing a file.
+type IOError struct {
+    Op       string
+    FileName string
+    Err      error
+}
+
+// Creates the error of an operation on a file. The operation and file name of a *fs.PathError are not repeated.
+func NewIOError(op string, fileName string, err error) *IOError {
+    var pathError *fs.PathError
+    if errors.As(err, &pathError) {
+        err = pathError.Err
+    }
+    return &IOError{Op: op, FileName: fileName, Err: err}
+}
+
+func (e *IOError) Error() string {
+    return fmt.Sprintf("%s %s: %v", e.Op, e.FileName, e.Err)
+}
+
+func (e *IOError) Unwrap() error {
+    return e.Err
+}
+
+// Checks the dataset has at least one sample with at least one feature besides its numberOfLabels labels, and that
+// all samples have the same length.
+func ValidateSamples(samples [][]float64, numberOfLabels int) error {
+    if len(samples) < 1 {
+        return ErrEmptyDataset
+    }
+    numberOfColumns := len(samples[0])
+    if numberOfColumns - numberOfLabels < 1 {
+        return ErrNoFeatures
+    }
+    for i, sample := range samples {
+        if len(sample) != numberOfColumns {
+            return &RowError{Row: i, Column: -1, Err: ErrRaggedRow}
+        }
+    }
+    return nil
+}
+
+// Checks the last numberOfLabels positions of the samples hold -1 or 1.
+func ValidateBinaryLabels(samples [][]float64, numberOfLabels int) error {
+    for i, sample := range samples {
+        for j := len(sample) - numberOfLabels; j < len(sample); j++ {
+            if sample[j] != -1 && sample[j] != 1 {
+                return &RowError{Row: i, Column: j, Err: fmt.Errorf("%w: %v is neither -1 nor 1", ErrInvalidLabel, sample[j])}
+            }
+        }
+    }
+    return nil
+}
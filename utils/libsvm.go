@@ -0,0 +1,118 @@
+package utils
+
+import (
+    "os"
+    "bufio"
+    "strconv"
+    "strings"
+    "fmt"
+)
+
+// Reads the samples of a LIBSVM (SVMlight) file, one per line as `label index:value ...` with the indexes starting at
+// 1 and increasing. The samples are dense, the missing features being 0 and the label being the last position. When
+// numberOfFeatures is 0 it is the largest index found; otherwise larger indexes are an error.
+// Comments following a '#' and the qid of ranking datasets are ignored.
+func ReadLIBSVMSamples(fileName string, numberOfFeatures int) ([][]float64, error) {
+    samples, err := ReadLIBSVMSparseSamples(fileName, numberOfFeatures)
+    if err != nil {
+        return nil, err
+    }
+    return samples.Dense(), nil
+}
+
+// Reads the samples of a LIBSVM (SVMlight) file keeping them sparse. The feature indexes of the file start at 1, those
+// of the samples at 0.
+func ReadLIBSVMSparseSamples(fileName string, numberOfFeatures int) (*SparseSamples, error) {
+    f, err := os.Open(fileName)
+    if err != nil {
+        return nil, NewIOError("open", fileName, err)
+    }
+    defer f.Close()
+    samples := NewSparseSamples(numberOfFeatures)
+    maxIndex := 0
+    var indices []int
+    var values []float64
+    scanner := bufio.NewScanner(f)
+    scanner.Buffer(make([]byte, 64 * 1024), 16 * 1024 * 1024)
+    for scanner.Scan() {
+        text := scanner.Text()
+        if i := strings.IndexByte(text, '#'); i >= 0 {
+            text = text[:i]
+        }
+        tokens := strings.Fields(text)
+        if len(tokens) == 0 {
+            continue
+        }
+        row := samples.NumberOfSamples()
+        label, err := strconv.ParseFloat(tokens[0], 64)
+        if err != nil {
+            return nil, &RowError{Row: row, Column: -1, Err: fmt.Errorf("%w: %q", ErrInvalidLabel, tokens[0])}
+        }
+        indices, values = indices[:0], values[:0]
+        previous := 0
+        for _, token := range tokens[1:] {
+            separator := strings.IndexByte(token, ':')
+            if separator < 0 {
+                return nil, &RowError{Row: row, Column: -1, Err: fmt.Errorf("%w: %q", ErrInvalidValue, token)}
+            }
+            if token[:separator] == "qid" {
+                continue
+            }
+            index, err := strconv.Atoi(token[:separator])
+            if err != nil || index <= previous || (numberOfFeatures > 0 && index > numberOfFeatures) {
+                return nil, &RowError{Row: row, Column: -1, Err: fmt.Errorf("%w: %q", ErrInvalidFeatureIndex, token)}
+            }
+            value, err := strconv.ParseFloat(token[separator + 1:], 64)
+            if err != nil {
+                return nil, &RowError{Row: row, Column: index - 1, Err: fmt.Errorf("%w: %q", ErrInvalidValue, token)}
+            }
+            indices = append(indices, index - 1)
+            values = append(values, value)
+            previous = index
+        }
+        if previous > maxIndex {
+            maxIndex = previous
+        }
+        samples.Append(indices, values, label)
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, NewIOError("read", fileName, err)
+    }
+    if samples.NumberOfSamples() < 1 {
+        return nil, ErrEmptyDataset
+    }
+    if numberOfFeatures == 0 {
+        samples.NumberOfFeatures = maxIndex
+    }
+    return samples, nil
+}
+
+// Writes samples, having the label at their last position, to a LIBSVM (SVMlight) file. Only the features other
+// than 0 are written.
+func WriteLIBSVMSamples(fileName string, samples [][]float64) error {
+    f, err := os.Create(fileName)
+    if err != nil {
+        return NewIOError("create", fileName, err)
+    }
+    w := bufio.NewWriter(f)
+    for _, sample := range samples {
+        label := len(sample) - 1
+        w.WriteString(strconv.FormatFloat(sample[label], 'g', -1, 64))
+        for featureNumber, value := range sample[:label] {
+            if value != 0 {
+                fmt.Fprintf(w, " %d:%s", featureNumber + 1, strconv.FormatFloat(value, 'g', -1, 64))
+            }
+        }
+        w.WriteByte('\n')
+    }
+    if err := w.Flush(); err != nil {
+        f.Close()
+        return NewIOError("write", fileName, err)
+    }
+
+    // The data may only reach the file when it is closed, so failing to close it fails the write.
+    if err := f.Close(); err != nil {
+        return NewIOError("close", fileName, err)
+    }
+    return nil
+}
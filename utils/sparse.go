@@ -0,0 +1,232 @@
+package utils
+
+import (
+    "fmt"
+    "sort"
+    "math"
+)
+
+// Samples stored in compressed sparse row (CSR) form, for datasets whose features are mostly 0.
+//
+// The features of sample i other than 0 are at Indices[Indptr[i]:Indptr[i + 1]], in increasing order, and their values
+// at the same positions of Values. The label of sample i is Labels[i], which would be the last position of a dense
+// sample.
+type SparseSamples struct {
+    NumberOfFeatures int
+    Indptr           []int
+    Indices          []int
+    Values           []float64
+    Labels           []float64
+}
+
+func NewSparseSamples(numberOfFeatures int) *SparseSamples {
+    return &SparseSamples{NumberOfFeatures: numberOfFeatures, Indptr: []int{0}}
+}
+
+// Converts dense samples, having the label at their last position.
+func NewSparseSamplesFromDense(samples [][]float64) *SparseSamples {
+    numberOfFeatures := 0
+    if len(samples) > 0 {
+        numberOfFeatures = len(samples[0]) - 1
+    }
+    s := NewSparseSamples(numberOfFeatures)
+    for _, sample := range samples {
+        label := len(sample) - 1
+        for featureNumber, value := range sample[:label] {
+            if value != 0 {
+                s.Indices = append(s.Indices, featureNumber)
+                s.Values = append(s.Values, value)
+            }
+        }
+        s.Labels = append(s.Labels, sample[label])
+        s.Indptr = append(s.Indptr, len(s.Indices))
+    }
+    return s
+}
+
+// Appends a sample given its features other than 0, in increasing order. Values equal to 0 are dropped.
+func (s *SparseSamples) Append(indices []int, values []float64, label float64) {
+    for i, index := range indices {
+        if values[i] != 0 {
+            s.Indices = append(s.Indices, index)
+            s.Values = append(s.Values, values[i])
+        }
+    }
+    s.Labels = append(s.Labels, label)
+    s.Indptr = append(s.Indptr, len(s.Indices))
+}
+
+func (s *SparseSamples) NumberOfSamples() int {
+    return len(s.Labels)
+}
+
+// Gets the features of sample i other than 0 and their values.
+func (s *SparseSamples) Row(i int) ([]int, []float64) {
+    return s.Indices[s.Indptr[i]:s.Indptr[i + 1]], s.Values[s.Indptr[i]:s.Indptr[i + 1]]
+}
+
+// Gets a new SparseSamples holding the given samples, in the given order. Samples can be repeated.
+func (s *SparseSamples) Select(rows []int) *SparseSamples {
+    selected := NewSparseSamples(s.NumberOfFeatures)
+    for _, i := range rows {
+        indices, values := s.Row(i)
+        selected.Append(indices, values, s.Labels[i])
+    }
+    return selected
+}
+
+// Converts the samples to dense ones, having the label at their last position.
+func (s *SparseSamples) Dense() [][]float64 {
+    samples := make([][]float64, s.NumberOfSamples())
+    for i := range samples {
+        samples[i] = make([]float64, s.NumberOfFeatures + 1)
+        indices, values := s.Row(i)
+        for j, index := range indices {
+            samples[i][index] = values[j]
+        }
+        samples[i][s.NumberOfFeatures] = s.Labels[i]
+    }
+    return samples
+}
+
+// Checks there is at least one sample with at least one feature, and that the features of every sample are in range
+// and increasing.
+func (s *SparseSamples) Validate() error {
+    if s.NumberOfSamples() < 1 {
+        return ErrEmptyDataset
+    }
+    if s.NumberOfFeatures < 1 {
+        return ErrNoFeatures
+    }
+    if len(s.Indptr) != s.NumberOfSamples() + 1 || len(s.Indices) != len(s.Values) || s.Indptr[len(s.Indptr) - 1] != len(s.Indices) {
+        return ErrRaggedRow
+    }
+    for i := 0; i < s.NumberOfSamples(); i++ {
+        indices, _ := s.Row(i)
+        previous := -1
+        for _, index := range indices {
+            if index <= previous || index >= s.NumberOfFeatures {
+                return &RowError{Row: i, Column: -1, Err: fmt.Errorf("%w: %d", ErrInvalidFeatureIndex, index)}
+            }
+            previous = index
+        }
+    }
+    return nil
+}
+
+// Checks the labels are -1 or 1.
+func (s *SparseSamples) ValidateBinaryLabels() error {
+    for i, label := range s.Labels {
+        if label != -1 && label != 1 {
+            return &RowError{Row: i, Column: s.NumberOfFeatures, Err: fmt.Errorf("%w: %v is neither -1 nor 1", ErrInvalidLabel, label)}
+        }
+    }
+    return nil
+}
+
+// Converts the samples to compressed sparse column (CSC) form. The samples of each column are in increasing order.
+func (s *SparseSamples) Columns() *SparseColumns {
+    c := &SparseColumns{
+        NumberOfSamples: s.NumberOfSamples(),
+        Indptr: make([]int, s.NumberOfFeatures + 1),
+        Rows: make([]int, len(s.Indices)),
+        Values: make([]float64, len(s.Values)),
+    }
+    for _, index := range s.Indices {
+        c.Indptr[index + 1]++
+    }
+    for featureNumber := 0; featureNumber < s.NumberOfFeatures; featureNumber++ {
+        c.Indptr[featureNumber + 1] += c.Indptr[featureNumber]
+    }
+    next := append([]int{}, c.Indptr[:s.NumberOfFeatures]...)
+    for i := 0; i < s.NumberOfSamples(); i++ {
+        indices, values := s.Row(i)
+        for j, index := range indices {
+            c.Rows[next[index]] = i
+            c.Values[next[index]] = values[j]
+            next[index]++
+        }
+    }
+    return c
+}
+
+// Reads sparse samples as dense ones, reusing a single vector so reading a sample takes time proportional to its
+// features other than 0.
+type RowBuffer struct {
+    samples *SparseSamples
+    vector  []float64
+    row     int
+}
+
+func (s *SparseSamples) NewRowBuffer() *RowBuffer {
+    return &RowBuffer{samples: s, vector: make([]float64, s.NumberOfFeatures + 1), row: -1}
+}
+
+// Gets sample i as a dense vector having the label at its last position. The vector is overwritten by the next call.
+func (b *RowBuffer) Row(i int) []float64 {
+    if b.row >= 0 {
+        indices, _ := b.samples.Row(b.row)
+        for _, index := range indices {
+            b.vector[index] = 0
+        }
+    }
+    indices, values := b.samples.Row(i)
+    for j, index := range indices {
+        b.vector[index] = values[j]
+    }
+    b.vector[b.samples.NumberOfFeatures] = b.samples.Labels[i]
+    b.row = i
+    return b.vector
+}
+
+// Samples stored in compressed sparse column (CSC) form.
+//
+// The samples whose feature j is not 0 are at Rows[Indptr[j]:Indptr[j + 1]] and their values at the same positions of
+// Values.
+type SparseColumns struct {
+    NumberOfSamples int
+    Indptr          []int
+    Rows            []int
+    Values          []float64
+}
+
+// Gets the samples whose feature is not 0 and their values.
+func (c *SparseColumns) Column(featureNumber int) ([]int, []float64) {
+    return c.Rows[c.Indptr[featureNumber]:c.Indptr[featureNumber + 1]], c.Values[c.Indptr[featureNumber]:c.Indptr[featureNumber + 1]]
+}
+
+func (c *SparseColumns) NumberOfFeatures() int {
+    return len(c.Indptr) - 1
+}
+
+// Sorts the samples of each column by their values, keeping the order of the samples holding the same value. Missing
+// values, NaN, are sorted last.
+func (c *SparseColumns) SortByValue() {
+    for featureNumber := 0; featureNumber < c.NumberOfFeatures(); featureNumber++ {
+        rows, values := c.Column(featureNumber)
+        sort.Stable(columnSorter{rows, values})
+    }
+}
+
+type columnSorter struct {
+    rows   []int
+    values []float64
+}
+
+func (s columnSorter) Len() int {
+    return len(s.rows)
+}
+
+func (s columnSorter) Less(i, j int) bool {
+    return LessWithNaNLast(s.values[i], s.values[j])
+}
+
+func (s columnSorter) Swap(i, j int) {
+    s.rows[i], s.rows[j] = s.rows[j], s.rows[i]
+    s.values[i], s.values[j] = s.values[j], s.values[i]
+}
+
+// Orders numbers increasingly, the NaNs being greater than all others.
+func LessWithNaNLast(a, b float64) bool {
+    return a < b || (!math.IsNaN(a) && math.IsNaN(b))
+}
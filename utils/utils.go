@@ -1,13 +1,6 @@
 package utils
 
 import (
-    "os"
-    "encoding/csv"
-    "bufio"
-    "log"
-    "strconv"
-    "io"
-    "fmt"
     "math/rand"
 )
 
@@ -24,29 +17,14 @@ func RemoveDuplicates(elements []uint) []uint {
     return result
 }
 
-func ReadSamples(fileName string) (d [][]float64) {
-    f, e := os.Open(fileName)
-    if e != nil {
-        log.Fatal(e)
+// Reads the samples of a CSV file, one per row, all values being numbers and the last one being the label.
+// A header row is skipped.
+func ReadSamples(fileName string) ([][]float64, error) {
+    dataset, _, err := ReadDataset(fileName, DefaultDatasetOptions())
+    if err != nil {
+        return nil, err
     }
-    r := csv.NewReader(bufio.NewReader(f))
-    for {
-        record, err := r.Read()
-        if err == io.EOF {
-            break
-        }
-        var entry []float64
-        for value := range record {
-            f, err := strconv.ParseFloat(record[value], 64)
-            if err == nil {
-                entry = append(entry, f)
-            } else {
-                fmt.Println(err)
-            }
-        }
-        d = append(d, entry)
-    }
-    return
+    return dataset.Samples, nil
 }
 
 func ShuffleSamples(samples [][]float64) {